@@ -2,23 +2,53 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
 	"os/signal"
 	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/avito/internship/pr-service/internal/config"
+	"github.com/avito/internship/pr-service/internal/handler"
+	auditHandler "github.com/avito/internship/pr-service/internal/handler/audit"
+	integrationHandler "github.com/avito/internship/pr-service/internal/handler/integration"
 	prHandler "github.com/avito/internship/pr-service/internal/handler/pullrequest"
+	statsHandler "github.com/avito/internship/pr-service/internal/handler/stats"
 	teamHandler "github.com/avito/internship/pr-service/internal/handler/team"
+	tokenHandler "github.com/avito/internship/pr-service/internal/handler/token"
 	userHandler "github.com/avito/internship/pr-service/internal/handler/user"
+	webhookHandler "github.com/avito/internship/pr-service/internal/handler/webhook"
+	"github.com/avito/internship/pr-service/internal/metrics"
+	"github.com/avito/internship/pr-service/internal/policy"
+	auditRepo "github.com/avito/internship/pr-service/internal/repository/audit"
+	identityRepo "github.com/avito/internship/pr-service/internal/repository/identity"
+	outboxRepo "github.com/avito/internship/pr-service/internal/repository/outbox"
 	prRepo "github.com/avito/internship/pr-service/internal/repository/pull-request"
+	rejectionRepo "github.com/avito/internship/pr-service/internal/repository/rejection"
+	statsRepo "github.com/avito/internship/pr-service/internal/repository/stats"
 	teamRepo "github.com/avito/internship/pr-service/internal/repository/team"
+	tokenRepo "github.com/avito/internship/pr-service/internal/repository/token"
 	userRepo "github.com/avito/internship/pr-service/internal/repository/user"
+	webhookRepo "github.com/avito/internship/pr-service/internal/repository/webhook"
 	"github.com/avito/internship/pr-service/internal/router"
+	"github.com/avito/internship/pr-service/internal/scheduler"
 	"github.com/avito/internship/pr-service/internal/server"
+	auditService "github.com/avito/internship/pr-service/internal/service/audit"
+	authService "github.com/avito/internship/pr-service/internal/service/auth"
+	integrationService "github.com/avito/internship/pr-service/internal/service/integration"
+	outboxService "github.com/avito/internship/pr-service/internal/service/outbox"
 	prService "github.com/avito/internship/pr-service/internal/service/pullrequest"
+	"github.com/avito/internship/pr-service/internal/service/selector"
+	"github.com/avito/internship/pr-service/internal/service/sla"
+	statsService "github.com/avito/internship/pr-service/internal/service/stats"
 	teamService "github.com/avito/internship/pr-service/internal/service/team"
 	userService "github.com/avito/internship/pr-service/internal/service/user"
+	webhookService "github.com/avito/internship/pr-service/internal/service/webhook"
 	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
 )
 
 func main() {
@@ -26,31 +56,215 @@ func main() {
 
 	dbPool := storage.InitDBPool(cfg)
 	defer dbPool.Close()
+	metrics.RegisterPoolStats(dbPool)
 
 	userRepository := userRepo.NewUserRepository(dbPool)
 	teamRepository := teamRepo.NewTeamRepository(dbPool)
 	pullRequestRepository := prRepo.NewPullRequestRepository(dbPool)
+	statsRepository := statsRepo.NewStatsRepository(dbPool)
+	webhookRepository := webhookRepo.NewWebhookRepository(dbPool)
+	outboxRepository := outboxRepo.NewOutboxRepository(dbPool)
+	identityRepository := identityRepo.NewIdentityRepository(dbPool)
+	rejectionRepository := rejectionRepo.NewRejectionRepository(dbPool)
+	auditRepository := auditRepo.NewAuditRepository(dbPool)
+	tokenRepository := tokenRepo.NewTokenRepository(dbPool)
 
-	userService := userService.NewUserService(userRepository)
-	teamService := teamService.NewTeamService(teamRepository)
-	pullRequestService := prService.NewPullRequestService(pullRequestRepository, userService, teamService)
+	txManager := storage.NewTxManager(dbPool)
 
-	userController := userHandler.NewUserController(userService, pullRequestService)
-	teamController := teamHandler.NewTeamController(teamService)
-	pullRequestController := prHandler.NewPullRequestController(pullRequestService)
+	webhookService := webhookService.NewWebhookService(webhookRepository, &http.Client{Timeout: cfg.Webhook.Timeout}, webhookService.DeliveryPolicy{
+		MaxAttempts: cfg.Webhook.MaxAttempts,
+		BackoffBase: cfg.Webhook.BackoffBase,
+		BackoffMax:  cfg.Webhook.BackoffMax,
+		Lease:       2 * cfg.Webhook.Timeout,
+	})
+	outboxService := outboxService.NewOutboxService(outboxRepository, txManager, outboxSinks(cfg.Outbox, webhookService), outboxService.RetryPolicy{
+		MaxAttempts: cfg.Outbox.MaxAttempts,
+		BackoffBase: cfg.Outbox.BackoffBase,
+		BackoffMax:  cfg.Outbox.BackoffMax,
+	})
+	slaCalendar, err := sla.NewCalendar(cfg.SLA.Timezone, cfg.SLA.WorkdayStart, cfg.SLA.WorkdayEnd)
+	if err != nil {
+		log.Fatalf("init sla calendar: %v", err)
+	}
+	reviewerSelector := selector.NewReviewerSelector(pullRequestRepository)
+	auditService := auditService.NewAuditService(auditRepository)
+	authService := authService.NewAuthService(tokenRepository, cfg.Auth.AdminToken)
+	pullRequestService := prService.NewPullRequestService(pullRequestRepository, userRepository, teamRepository, reviewerSelector, txManager, outboxService, auditService, slaCalendar)
+	userService := userService.NewUserService(userRepository, pullRequestService, txManager, auditService)
+	teamService := teamService.NewTeamService(teamRepository, pullRequestService, txManager, auditService)
+	statsService := statsService.NewStatsService(statsRepository)
+	integrationService := integrationService.NewIntegrationService(identityRepository, rejectionRepository, pullRequestService, integrationService.Secrets{
+		GitHubWebhookSecrets: tenantSecrets(cfg.Integrations.GitHubWebhookSecrets, cfg.Integrations.GitHubWebhookSecret),
+		GitLabWebhookTokens:  tenantSecrets(cfg.Integrations.GitLabWebhookTokens, cfg.Integrations.GitLabWebhookToken),
+	})
 
+	userPolicy := policy.NewUserPolicy(userService)
+	teamPolicy := policy.NewTeamPolicy(teamService, userService)
+	pullRequestPolicy := policy.NewPullRequestPolicy(pullRequestService, userService)
+	webhookPolicy := policy.NewWebhookPolicy(webhookService)
+	integrationPolicy := policy.NewIntegrationPolicy(integrationService)
+	auditPolicy := policy.NewAuditPolicy(auditService)
+
+	userController := userHandler.NewUserController(userPolicy, pullRequestService)
+	teamController := teamHandler.NewTeamController(teamPolicy)
+	pullRequestController := prHandler.NewPullRequestController(pullRequestPolicy)
+	statsController := statsHandler.NewStatsController(statsService)
+	webhookController := webhookHandler.NewWebhookController(webhookPolicy)
+	integrationController := integrationHandler.NewIntegrationController(integrationPolicy)
+	auditController := auditHandler.NewAuditController(auditPolicy)
+	tokenController := tokenHandler.NewTokenController(authService)
+
+	authenticate := handler.Authenticate(authenticator(cfg.Auth, authService))
 	mux := router.InitRouter()
-	router.SetupUserRoutes(mux, userController)
-	router.SetupTeamRoutes(mux, teamController)
-	router.SetupPullRequestRoutes(mux, pullRequestController)
+	api := mux.With(authenticate)
+	router.SetupUserRoutes(api, userController)
+	router.SetupTeamRoutes(api, teamController)
+	router.SetupPullRequestRoutes(api, pullRequestController)
+	router.SetupStatsRoutes(api, statsController)
+	router.SetupWebhookRoutes(api, webhookController)
+	router.SetupIntegrationRoutes(mux, integrationController, authenticate)
+	router.SetupAuditRoutes(api, auditController)
+	router.SetupTokenRoutes(api, tokenController)
+
+	jobs := scheduler.NewScheduler(
+		scheduler.Job{
+			Name:     "fill missing reviewers",
+			Interval: cfg.Scheduler.FillReviewersInterval,
+			Run: perTenant(teamRepository, func(ctx context.Context) error {
+				return pullRequestService.FillMissingReviewers(ctx, cfg.Scheduler.FillReviewersBatch)
+			}),
+		},
+		scheduler.Job{
+			Name:     "escalate overdue reviews",
+			Interval: cfg.Scheduler.EscalationInterval,
+			Run: perTenant(teamRepository, func(ctx context.Context) error {
+				report, err := pullRequestService.EscalateOverdueReviews(ctx, cfg.Scheduler.EscalationBatch)
+				if err != nil {
+					return err
+				}
+				if len(report.Reassigned) > 0 || len(report.Failed) > 0 {
+					log.Printf("escalated %d overdue reviews in tenant %s, %d without candidates", len(report.Reassigned), tenant.FromContext(ctx), len(report.Failed))
+				}
+				return nil
+			}),
+		},
+		scheduler.Job{
+			Name:     "relay outbox events",
+			Interval: cfg.Outbox.RelayInterval,
+			Run: func(ctx context.Context) error {
+				_, err := outboxService.Relay(ctx, cfg.Outbox.RelayBatch)
+				return err
+			},
+		},
+		scheduler.Job{
+			Name:     "deliver webhooks",
+			Interval: cfg.Webhook.DeliveryInterval,
+			Run: func(ctx context.Context) error {
+				return webhookService.DeliverPending(ctx, cfg.Webhook.DeliveryBatch)
+			},
+		},
+	)
 
 	srv := server.NewServer(cfg.Server.Port, mux)
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
 	go srv.Start()
+	jobs.Start()
 	log.Println("service started")
 
 	<-ctx.Done()
 	srv.GracefulShutdown()
+	jobs.Stop()
+}
+
+func perTenant(teamRepository *teamRepo.TeamRepository, run func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		tenantIDs, err := teamRepository.ListTenants(ctx)
+		if err != nil {
+			return fmt.Errorf("list tenants: %w", err)
+		}
+		return tenant.ForEach(ctx, tenantIDs, run)
+	}
+}
+
+func tenantSecrets(secrets map[string]string, defaultSecret string) map[string]string {
+	result := make(map[string]string, len(secrets)+1)
+	for tenantID, secret := range secrets {
+		result[tenantID] = secret
+	}
+	if _, ok := result[tenant.Default]; !ok && defaultSecret != "" {
+		result[tenant.Default] = defaultSecret
+	}
+	return result
+}
+
+func outboxSinks(cfg config.OutboxConfig, webhookSink outboxService.Sink) []outboxService.Sink {
+	sinks := make([]outboxService.Sink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case "log":
+			sinks = append(sinks, outboxService.NewLogSink())
+		case "webhook":
+			sinks = append(sinks, webhookSink)
+		case "file":
+			sinks = append(sinks, outboxService.NewFileSink(cfg.FilePath))
+		default:
+			log.Fatalf("unknown outbox sink %q", name)
+		}
+	}
+	return sinks
+}
+
+func authenticator(cfg config.AuthConfig, tokenAuthenticator handler.Authenticator) handler.Authenticator {
+	switch cfg.Mode {
+	case "token":
+		return tokenAuthenticator
+	case "jwt":
+		keys, err := jwks(cfg.JWT)
+		if err != nil {
+			log.Fatalf("load jwks: %v", err)
+		}
+		jwtAuthenticator, err := authService.NewJWTAuthenticator(keys, authService.JWTOptions{
+			Audience:           cfg.JWT.Audience,
+			Issuer:             cfg.JWT.Issuer,
+			UserClaim:          cfg.JWT.UserClaim,
+			RoleClaim:          cfg.JWT.RoleClaim,
+			TenantClaim:        cfg.JWT.TenantClaim,
+			Leeway:             cfg.JWT.Leeway,
+			RefreshKeys:        jwksRefresher(cfg.JWT),
+			MinRefreshInterval: cfg.JWT.JWKSRefreshInterval,
+		})
+		if err != nil {
+			log.Fatalf("init jwt authenticator: %v", err)
+		}
+		return jwtAuthenticator
+	default:
+		log.Fatalf("unknown auth mode %q", cfg.Mode)
+		return nil
+	}
+}
+
+func jwks(cfg config.JWTConfig) (authService.KeySet, error) {
+	if cfg.JWKSFile != "" {
+		return authService.LoadJWKSFile(cfg.JWKSFile)
+	}
+	if cfg.JWKSURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return authService.FetchJWKS(ctx, &http.Client{}, cfg.JWKSURL)
+	}
+	return nil, errors.New("JWT_JWKS_FILE or JWT_JWKS_URL is required")
+}
+
+func jwksRefresher(cfg config.JWTConfig) func(ctx context.Context) (authService.KeySet, error) {
+	if cfg.JWKSFile != "" {
+		return func(ctx context.Context) (authService.KeySet, error) {
+			return authService.LoadJWKSFile(cfg.JWKSFile)
+		}
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(ctx context.Context) (authService.KeySet, error) {
+		return authService.FetchJWKS(context.WithoutCancel(ctx), client, cfg.JWKSURL)
+	}
 }
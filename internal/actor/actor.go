@@ -0,0 +1,25 @@
+package actor
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/principal"
+)
+
+const System = "system"
+
+type contextKey struct{}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, contextKey{}, actor)
+}
+
+func FromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(contextKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	if p, ok := principal.FromContext(ctx); ok {
+		return p.Subject
+	}
+	return System
+}
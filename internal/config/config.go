@@ -2,14 +2,21 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DbConfig
+	Server       ServerConfig
+	Database     DbConfig
+	Scheduler    SchedulerConfig
+	SLA          SLAConfig
+	Webhook      WebhookConfig
+	Outbox       OutboxConfig
+	Integrations IntegrationsConfig
+	Auth         AuthConfig
 }
 
 type ServerConfig struct {
@@ -25,6 +32,63 @@ type DbConfig struct {
 	DB       string `env:"DB_NAME" env-required:"true"`
 }
 
+type SLAConfig struct {
+	Timezone     string        `env:"SLA_TIMEZONE" env-default:"UTC"`
+	WorkdayStart time.Duration `env:"SLA_WORKDAY_START" env-default:"9h"`
+	WorkdayEnd   time.Duration `env:"SLA_WORKDAY_END" env-default:"18h"`
+}
+
+type SchedulerConfig struct {
+	FillReviewersInterval time.Duration `env:"FILL_REVIEWERS_INTERVAL" env-default:"1m"`
+	FillReviewersBatch    int           `env:"FILL_REVIEWERS_BATCH" env-default:"100"`
+	EscalationInterval    time.Duration `env:"ESCALATION_INTERVAL" env-default:"5m"`
+	EscalationBatch       int           `env:"ESCALATION_BATCH" env-default:"100"`
+}
+
+type WebhookConfig struct {
+	DeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" env-default:"5s"`
+	DeliveryBatch    int           `env:"WEBHOOK_DELIVERY_BATCH" env-default:"50"`
+	MaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+	BackoffBase      time.Duration `env:"WEBHOOK_BACKOFF_BASE" env-default:"10s"`
+	BackoffMax       time.Duration `env:"WEBHOOK_BACKOFF_MAX" env-default:"1h"`
+	Timeout          time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+}
+
+type OutboxConfig struct {
+	RelayInterval time.Duration `env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+	RelayBatch    int           `env:"OUTBOX_RELAY_BATCH" env-default:"100"`
+	Sinks         []string      `env:"OUTBOX_SINKS" env-separator:"," env-default:"log,webhook"`
+	FilePath      string        `env:"OUTBOX_FILE_PATH" env-default:"outbox_events.jsonl"`
+	MaxAttempts   int           `env:"OUTBOX_MAX_ATTEMPTS" env-default:"10"`
+	BackoffBase   time.Duration `env:"OUTBOX_BACKOFF_BASE" env-default:"5s"`
+	BackoffMax    time.Duration `env:"OUTBOX_BACKOFF_MAX" env-default:"30m"`
+}
+
+type IntegrationsConfig struct {
+	GitHubWebhookSecret  string            `env:"GITHUB_WEBHOOK_SECRET"`
+	GitLabWebhookToken   string            `env:"GITLAB_WEBHOOK_TOKEN"`
+	GitHubWebhookSecrets map[string]string `env:"GITHUB_WEBHOOK_SECRETS"`
+	GitLabWebhookTokens  map[string]string `env:"GITLAB_WEBHOOK_TOKENS"`
+}
+
+type AuthConfig struct {
+	Mode       string `env:"AUTH_MODE" env-default:"token"`
+	AdminToken string `env:"AUTH_ADMIN_TOKEN"`
+	JWT        JWTConfig
+}
+
+type JWTConfig struct {
+	JWKSFile            string        `env:"JWT_JWKS_FILE"`
+	JWKSURL             string        `env:"JWT_JWKS_URL"`
+	JWKSRefreshInterval time.Duration `env:"JWT_JWKS_REFRESH_INTERVAL" env-default:"1m"`
+	Audience            string        `env:"JWT_AUDIENCE"`
+	Issuer              string        `env:"JWT_ISSUER"`
+	UserClaim           string        `env:"JWT_USER_CLAIM" env-default:"sub"`
+	RoleClaim           string        `env:"JWT_ROLE_CLAIM" env-default:"role"`
+	TenantClaim         string        `env:"JWT_TENANT_CLAIM" env-default:"tenant_id"`
+	Leeway              time.Duration `env:"JWT_LEEWAY" env-default:"30s"`
+}
+
 func NewConfig() *Config {
 	err := godotenv.Load()
 	if err != nil {
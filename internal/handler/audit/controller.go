@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/handler"
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type AuditController struct {
+	auditService AuditService
+}
+
+func NewAuditController(auditService AuditService) *AuditController {
+	return &AuditController{auditService: auditService}
+}
+
+func (c *AuditController) List(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		return err
+	}
+
+	events, err := c.auditService.List(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"events": ToAuditEventDTOs(events)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func parseAuditFilter(r *http.Request) (model.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := model.AuditFilter{
+		PullRequestID: query.Get("pull_request_id"),
+		UserID:        query.Get("user_id"),
+		TeamName:      query.Get("team_name"),
+		Action:        model.AuditAction(query.Get("action")),
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(r, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTimeParam(r, "to"); err != nil {
+		return filter, err
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, model.ErrInvalidLimit
+		}
+	}
+	return filter, nil
+}
+
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, model.ErrInvalidTimeRange
+	}
+	return &t, nil
+}
@@ -0,0 +1,23 @@
+package audit
+
+import "github.com/avito/internship/pr-service/internal/model"
+
+func ToAuditEventDTOs(events []model.AuditEvent) []AuditEventDTO {
+	dtos := make([]AuditEventDTO, 0, len(events))
+	for _, event := range events {
+		dtos = append(dtos, AuditEventDTO{
+			ID:            event.ID,
+			Actor:         event.Actor,
+			Action:        event.Action,
+			TargetType:    event.TargetType,
+			TargetID:      event.TargetID,
+			PullRequestID: event.PullRequestID,
+			UserID:        event.UserID,
+			TeamName:      event.TeamName,
+			Before:        event.Before,
+			After:         event.After,
+			CreatedAt:     event.CreatedAt,
+		})
+	}
+	return dtos
+}
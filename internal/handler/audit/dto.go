@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type AuditEventDTO struct {
+	ID            int64             `json:"id"`
+	Actor         string            `json:"actor"`
+	Action        model.AuditAction `json:"action"`
+	TargetType    model.AuditTarget `json:"target_type"`
+	TargetID      string            `json:"target_id"`
+	PullRequestID string            `json:"pull_request_id,omitempty"`
+	UserID        string            `json:"user_id,omitempty"`
+	TeamName      string            `json:"team_name,omitempty"`
+	Before        any               `json:"before"`
+	After         any               `json:"after"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
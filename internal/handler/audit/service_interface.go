@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type AuditService interface {
+	List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, error)
+}
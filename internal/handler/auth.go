@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*model.Principal, error)
+}
+
+func Authenticate(authenticator Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				renderError(w, model.ErrUnauthenticated)
+				return
+			}
+
+			p, err := authenticator.Authenticate(r.Context(), token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				renderError(w, err)
+				return
+			}
+			if p.Role != model.RoleAdmin && p.TenantID == "" {
+				renderError(w, model.ErrTenantRequired)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(principal.WithPrincipal(r.Context(), p)))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -19,13 +19,18 @@ func ErrorHandler(h HandlerFunc) http.HandlerFunc {
 }
 
 var errorStatusMap = map[string]int{
-	"NOT_FOUND":    http.StatusNotFound,
-	"TEAM_EXISTS":  http.StatusBadRequest,
-	"PR_EXISTS":    http.StatusConflict,
-	"PR_MERGED":    http.StatusConflict,
-	"NOT_ASSIGNED": http.StatusConflict,
-	"NO_CANDIDATE": http.StatusConflict,
-	"BAD_REQUEST":  http.StatusBadRequest,
+	"NOT_FOUND":     http.StatusNotFound,
+	"TEAM_EXISTS":   http.StatusBadRequest,
+	"PR_EXISTS":     http.StatusConflict,
+	"PR_MERGED":     http.StatusConflict,
+	"PR_CLOSED":     http.StatusConflict,
+	"PR_DRAFT":      http.StatusConflict,
+	"MERGE_BLOCKED": http.StatusConflict,
+	"NOT_ASSIGNED":  http.StatusConflict,
+	"NO_CANDIDATE":  http.StatusConflict,
+	"BAD_REQUEST":   http.StatusBadRequest,
+	"UNAUTHORIZED":  http.StatusUnauthorized,
+	"FORBIDDEN":     http.StatusForbidden,
 }
 
 func renderError(w http.ResponseWriter, err error) {
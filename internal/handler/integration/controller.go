@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/avito/internship/pr-service/internal/handler"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/go-chi/chi/v5"
+)
+
+const maxWebhookBodySize = 5 << 20
+
+type IntegrationController struct {
+	integrationService IntegrationService
+}
+
+func NewIntegrationController(integrationService IntegrationService) *IntegrationController {
+	return &IntegrationController{integrationService: integrationService}
+}
+
+func (c *IntegrationController) GitHubWebhook(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		return model.ErrBadWebhookPayload
+	}
+
+	result, err := c.integrationService.HandleGitHubWebhook(webhookContext(r), r.Header.Get("X-GitHub-Event"), r.Header.Get("X-Hub-Signature-256"), body)
+	if err != nil {
+		return err
+	}
+
+	writeIntegrationResult(w, result)
+	return nil
+}
+
+func (c *IntegrationController) GitLabWebhook(w http.ResponseWriter, r *http.Request) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		return model.ErrBadWebhookPayload
+	}
+
+	result, err := c.integrationService.HandleGitLabWebhook(webhookContext(r), r.Header.Get("X-Gitlab-Token"), body)
+	if err != nil {
+		return err
+	}
+
+	writeIntegrationResult(w, result)
+	return nil
+}
+
+func (c *IntegrationController) ListRejections(w http.ResponseWriter, r *http.Request) error {
+	provider := model.ExternalProvider(r.URL.Query().Get("provider"))
+
+	rejections, err := c.integrationService.ListRejections(r.Context(), provider)
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"rejections": ToIntegrationRejectionDTOs(rejections)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func (c *IntegrationController) SaveIdentity(w http.ResponseWriter, r *http.Request) error {
+	var req SaveIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	identity, err := c.integrationService.SaveIdentity(r.Context(), req.ToModel())
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"identity": ToExternalIdentityDTO(identity)}
+	handler.WriteJSONResponse(w, http.StatusCreated, response)
+	return nil
+}
+
+func (c *IntegrationController) ListIdentities(w http.ResponseWriter, r *http.Request) error {
+	provider := model.ExternalProvider(r.URL.Query().Get("provider"))
+
+	identities, err := c.integrationService.ListIdentities(r.Context(), provider)
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"identities": ToExternalIdentityDTOs(identities)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func (c *IntegrationController) DeleteIdentity(w http.ResponseWriter, r *http.Request) error {
+	var req DeleteIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	if err := c.integrationService.DeleteIdentity(r.Context(), req.Provider, req.Login); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func writeIntegrationResult(w http.ResponseWriter, result *model.IntegrationResult) {
+	status := http.StatusOK
+	if result.Status != model.IntegrationProcessed {
+		status = http.StatusAccepted
+	}
+	handler.WriteJSONResponse(w, status, ToIntegrationResultDTO(result))
+}
+
+func webhookContext(r *http.Request) context.Context {
+	tenantID := chi.URLParam(r, "tenant")
+	if tenantID == "" {
+		tenantID = tenant.Default
+	}
+	return tenant.WithTenant(r.Context(), tenantID)
+}
@@ -0,0 +1,61 @@
+package integration
+
+import "github.com/avito/internship/pr-service/internal/model"
+
+func (r SaveIdentityRequest) ToModel() *model.ExternalIdentity {
+	return &model.ExternalIdentity{
+		Provider:      r.Provider,
+		ExternalLogin: r.Login,
+		UserID:        r.UserID,
+	}
+}
+
+func ToExternalIdentityDTO(identity *model.ExternalIdentity) ExternalIdentityDTO {
+	return ExternalIdentityDTO{
+		Provider:  identity.Provider,
+		Login:     identity.ExternalLogin,
+		UserID:    identity.UserID,
+		CreatedAt: identity.CreatedAt,
+	}
+}
+
+func ToExternalIdentityDTOs(identities []model.ExternalIdentity) []ExternalIdentityDTO {
+	dtos := make([]ExternalIdentityDTO, 0, len(identities))
+	for i := range identities {
+		dtos = append(dtos, ToExternalIdentityDTO(&identities[i]))
+	}
+	return dtos
+}
+
+func ToIntegrationResultDTO(result *model.IntegrationResult) IntegrationResultDTO {
+	dto := IntegrationResultDTO{
+		Status: result.Status,
+		Action: result.Action,
+	}
+	if result.PullRequest != nil {
+		dto.PullRequestID = result.PullRequest.PullRequestID
+	}
+	if result.Rejection != nil {
+		dto.PullRequestID = result.Rejection.PullRequestID
+		dto.RejectionID = result.Rejection.ID
+		dto.Reason = result.Rejection.Reason
+	}
+	return dto
+}
+
+func ToIntegrationRejectionDTOs(rejections []model.IntegrationRejection) []IntegrationRejectionDTO {
+	dtos := make([]IntegrationRejectionDTO, 0, len(rejections))
+	for _, rejection := range rejections {
+		dtos = append(dtos, IntegrationRejectionDTO{
+			ID:            rejection.ID,
+			Provider:      rejection.Provider,
+			Action:        rejection.Action,
+			PullRequestID: rejection.PullRequestID,
+			Login:         rejection.ExternalLogin,
+			Reason:        rejection.Reason,
+			Payload:       rejection.Payload,
+			CreatedAt:     rejection.CreatedAt,
+		})
+	}
+	return dtos
+}
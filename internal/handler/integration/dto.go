@@ -0,0 +1,45 @@
+package integration
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type ExternalIdentityDTO struct {
+	Provider  model.ExternalProvider `json:"provider"`
+	Login     string                 `json:"login"`
+	UserID    string                 `json:"user_id"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type SaveIdentityRequest struct {
+	Provider model.ExternalProvider `json:"provider"`
+	Login    string                 `json:"login"`
+	UserID   string                 `json:"user_id"`
+}
+
+type DeleteIdentityRequest struct {
+	Provider model.ExternalProvider `json:"provider"`
+	Login    string                 `json:"login"`
+}
+
+type IntegrationResultDTO struct {
+	Status        model.IntegrationStatus `json:"status"`
+	Action        model.ExternalAction    `json:"action,omitempty"`
+	PullRequestID string                  `json:"pull_request_id,omitempty"`
+	RejectionID   int64                   `json:"rejection_id,omitempty"`
+	Reason        string                  `json:"reason,omitempty"`
+}
+
+type IntegrationRejectionDTO struct {
+	ID            int64                  `json:"id"`
+	Provider      model.ExternalProvider `json:"provider"`
+	Action        model.ExternalAction   `json:"action"`
+	PullRequestID string                 `json:"pull_request_id"`
+	Login         string                 `json:"login"`
+	Reason        string                 `json:"reason"`
+	Payload       json.RawMessage        `json:"payload"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package integration
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type IntegrationService interface {
+	SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error)
+	ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error)
+	DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error
+	ListRejections(ctx context.Context, provider model.ExternalProvider) ([]model.IntegrationRejection, error)
+	HandleGitHubWebhook(ctx context.Context, eventName, signature string, body []byte) (*model.IntegrationResult, error)
+	HandleGitLabWebhook(ctx context.Context, token string, body []byte) (*model.IntegrationResult, error)
+}
@@ -22,7 +22,7 @@ func (c *PullRequestController) Create(w http.ResponseWriter, r *http.Request) e
 		return model.ErrBadJSONRequest
 	}
 
-	pr, err := c.pullRequestService.Create(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := c.pullRequestService.Create(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, req.IsDraft)
 	if err != nil {
 		return err
 	}
@@ -38,7 +38,10 @@ func (c *PullRequestController) Merge(w http.ResponseWriter, r *http.Request) er
 		return model.ErrBadJSONRequest
 	}
 
-	pr, err := c.pullRequestService.Merge(r.Context(), req.PullRequestID)
+	pr, err := c.pullRequestService.Merge(r.Context(), req.PullRequestID, model.MergeOptions{
+		MergedBy: req.MergedBy,
+		Override: req.AdminOverride,
+	})
 	if err != nil {
 		return err
 	}
@@ -47,6 +50,88 @@ func (c *PullRequestController) Merge(w http.ResponseWriter, r *http.Request) er
 	return nil
 }
 
+func (c *PullRequestController) Close(w http.ResponseWriter, r *http.Request) error {
+	var req ClosePullRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	pr, err := c.pullRequestService.Close(r.Context(), req.PullRequestID)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToPullRequestDTO(pr))
+	return nil
+}
+
+func (c *PullRequestController) Reopen(w http.ResponseWriter, r *http.Request) error {
+	var req ReopenPullRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	pr, err := c.pullRequestService.Reopen(r.Context(), req.PullRequestID)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToPullRequestDTO(pr))
+	return nil
+}
+
+func (c *PullRequestController) MarkReady(w http.ResponseWriter, r *http.Request) error {
+	var req MarkReadyPullRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	pr, err := c.pullRequestService.MarkReady(r.Context(), req.PullRequestID)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToPullRequestDTO(pr))
+	return nil
+}
+
+func (c *PullRequestController) Review(w http.ResponseWriter, r *http.Request) error {
+	var req ReviewPullRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	pr, err := c.pullRequestService.SubmitReview(r.Context(), req.PullRequestID, req.ReviewerID, req.State)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToPullRequestDTO(pr))
+	return nil
+}
+
+func (c *PullRequestController) GetOverdueReviews(w http.ResponseWriter, r *http.Request) error {
+	reviews, err := c.pullRequestService.GetOverdueReviews(r.Context())
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"overdue": ToOverdueReviewDTOs(reviews)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func (c *PullRequestController) GetHistory(w http.ResponseWriter, r *http.Request) error {
+	prID := r.URL.Query().Get("pull_request_id")
+	history, err := c.pullRequestService.GetHistory(r.Context(), prID)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToPullRequestHistoryDTO(history))
+	return nil
+}
+
 func (c *PullRequestController) Reassign(w http.ResponseWriter, r *http.Request) error {
 	var req ReassignPullRequestRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
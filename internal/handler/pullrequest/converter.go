@@ -9,6 +9,83 @@ func ToPullRequestDTO(pr *model.PullRequest) *PullRequestDTO {
 		AuthorID:          pr.AuthorID,
 		Status:            pr.Status,
 		AssignedReviewers: pr.AssignedReviewers,
+		Reviews:           ToReviewDTOs(pr.Reviews),
 		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
+		MergedBy:          pr.MergedBy,
+		MergeOverride:     pr.MergeOverride,
+	}
+}
+
+func ToReviewDTOs(reviews []model.Review) []ReviewDTO {
+	dtos := make([]ReviewDTO, 0, len(reviews))
+	for _, review := range reviews {
+		dtos = append(dtos, ReviewDTO{
+			ReviewerID: review.ReviewerID,
+			State:      review.State,
+			AssignedAt: review.AssignedAt,
+			DueAt:      review.DueAt,
+			ReviewedAt: review.ReviewedAt,
+		})
+	}
+	return dtos
+}
+
+func ToOverdueReviewDTOs(reviews []model.OverdueReview) []OverdueReviewDTO {
+	dtos := make([]OverdueReviewDTO, 0, len(reviews))
+	for _, review := range reviews {
+		dtos = append(dtos, OverdueReviewDTO{
+			PullRequestID:   review.PullRequestID,
+			PullRequestName: review.PullRequestName,
+			AuthorID:        review.AuthorID,
+			ReviewerID:      review.ReviewerID,
+			AssignedAt:      review.AssignedAt,
+			DueAt:           review.DueAt,
+		})
+	}
+	return dtos
+}
+
+func ToPullRequestHistoryDTO(history *model.PullRequestHistory) *PullRequestHistoryDTO {
+	assignments := make([]ReviewerAssignmentDTO, 0, len(history.Assignments))
+	for _, assignment := range history.Assignments {
+		assignments = append(assignments, ReviewerAssignmentDTO{
+			ReviewerID:   assignment.ReviewerID,
+			Reason:       assignment.Reason,
+			State:        assignment.State,
+			AssignedAt:   assignment.AssignedAt,
+			UnassignedAt: assignment.UnassignedAt,
+			DueAt:        assignment.DueAt,
+			ReviewedAt:   assignment.ReviewedAt,
+		})
+	}
+	return &PullRequestHistoryDTO{
+		PullRequestID: history.PullRequestID,
+		History:       assignments,
+	}
+}
+
+func ToReassignmentReportDTO(report *model.ReassignmentReport) *ReassignmentReportDTO {
+	reassigned := make([]ReviewReassignmentDTO, 0, len(report.Reassigned))
+	for _, r := range report.Reassigned {
+		reassigned = append(reassigned, ReviewReassignmentDTO{
+			PullRequestID: r.PullRequestID,
+			OldReviewerID: r.OldReviewerID,
+			NewReviewerID: r.NewReviewerID,
+		})
+	}
+
+	failed := make([]ReassignmentFailureDTO, 0, len(report.Failed))
+	for _, f := range report.Failed {
+		failed = append(failed, ReassignmentFailureDTO{
+			PullRequestID: f.PullRequestID,
+			ReviewerID:    f.ReviewerID,
+			Reason:        f.Reason,
+		})
+	}
+
+	return &ReassignmentReportDTO{
+		Reassigned: reassigned,
+		Failed:     failed,
 	}
 }
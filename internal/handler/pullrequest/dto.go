@@ -12,7 +12,49 @@ type PullRequestDTO struct {
 	AuthorID          string                  `json:"author_id"`
 	Status            model.PullRequestStatus `json:"status"`
 	AssignedReviewers []string                `json:"assigned_reviewers"`
+	Reviews           []ReviewDTO             `json:"reviews"`
 	MergedAt          *time.Time              `json:"merged_at,omitempty"`
+	ClosedAt          *time.Time              `json:"closed_at,omitempty"`
+	MergedBy          *string                 `json:"merged_by,omitempty"`
+	MergeOverride     bool                    `json:"merge_override,omitempty"`
+}
+
+type ReviewDTO struct {
+	ReviewerID string            `json:"reviewer_id"`
+	State      model.ReviewState `json:"state"`
+	AssignedAt time.Time         `json:"assigned_at"`
+	DueAt      *time.Time        `json:"due_at,omitempty"`
+	ReviewedAt *time.Time        `json:"reviewed_at,omitempty"`
+}
+
+type OverdueReviewDTO struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	ReviewerID      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	DueAt           time.Time `json:"due_at"`
+}
+
+type ReviewerAssignmentDTO struct {
+	ReviewerID   string                 `json:"reviewer_id"`
+	Reason       model.AssignmentReason `json:"reason"`
+	State        model.ReviewState      `json:"state"`
+	AssignedAt   time.Time              `json:"assigned_at"`
+	UnassignedAt *time.Time             `json:"unassigned_at"`
+	DueAt        *time.Time             `json:"due_at,omitempty"`
+	ReviewedAt   *time.Time             `json:"reviewed_at,omitempty"`
+}
+
+type PullRequestHistoryDTO struct {
+	PullRequestID string                  `json:"pull_request_id"`
+	History       []ReviewerAssignmentDTO `json:"history"`
+}
+
+type ReviewPullRequestRequest struct {
+	PullRequestID string            `json:"pull_request_id"`
+	ReviewerID    string            `json:"reviewer_id"`
+	State         model.ReviewState `json:"state"`
 }
 
 type ReassignPullRequestRequest struct {
@@ -29,8 +71,40 @@ type CreatePullRequestRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	IsDraft         bool   `json:"is_draft"`
 }
 
 type MergePullRequestRequest struct {
 	PullRequestID string `json:"pull_request_id"`
+	MergedBy      string `json:"merged_by"`
+	AdminOverride bool   `json:"admin_override"`
+}
+
+type ClosePullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type ReopenPullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type MarkReadyPullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type ReviewReassignmentDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type ReassignmentFailureDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Reason        string `json:"reason"`
+}
+
+type ReassignmentReportDTO struct {
+	Reassigned []ReviewReassignmentDTO  `json:"reassigned"`
+	Failed     []ReassignmentFailureDTO `json:"failed"`
 }
@@ -7,7 +7,13 @@ import (
 )
 
 type PullRequestService interface {
-	Create(ctx context.Context, prID, prName, authoЯrID string) (*model.PullRequest, error)
-	Merge(ctx context.Context, prID string) (*model.PullRequest, error)
+	Create(ctx context.Context, prID, prName, authorID string, isDraft bool) (*model.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (*model.PullRequest, error)
+	Merge(ctx context.Context, prID string, opts model.MergeOptions) (*model.PullRequest, error)
+	Close(ctx context.Context, prID string) (*model.PullRequest, error)
+	Reopen(ctx context.Context, prID string) (*model.PullRequest, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, state model.ReviewState) (*model.PullRequest, error)
+	GetOverdueReviews(ctx context.Context) ([]model.OverdueReview, error)
+	GetHistory(ctx context.Context, prID string) (*model.PullRequestHistory, error)
 	Reassign(ctx context.Context, prID, oldReviewerID string) (*model.ReassignResponse, error)
 }
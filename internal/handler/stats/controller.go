@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/handler"
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type StatsController struct {
+	statsService StatsService
+}
+
+func NewStatsController(statsService StatsService) *StatsController {
+	return &StatsController{statsService: statsService}
+}
+
+func (c *StatsController) GetAssignmentStats(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		return err
+	}
+
+	report, err := c.statsService.GetAssignmentStats(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToAssignmentStatsResponse(report))
+	return nil
+}
+
+func (c *StatsController) GetPullRequestStats(w http.ResponseWriter, r *http.Request) error {
+	statsFilter, err := parseStatsFilter(r)
+	if err != nil {
+		return err
+	}
+
+	filter := model.PullRequestStatsFilter{StatsFilter: statsFilter}
+	if value := r.URL.Query().Get("open_longer_than"); value != "" {
+		if filter.OpenThreshold, err = time.ParseDuration(value); err != nil {
+			return model.ErrInvalidThreshold
+		}
+	}
+
+	report, err := c.statsService.GetPullRequestStats(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToPullRequestStatsResponse(report))
+	return nil
+}
+
+func parseStatsFilter(r *http.Request) (model.StatsFilter, error) {
+	var filter model.StatsFilter
+	var err error
+	if filter.From, err = parseTimeParam(r, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTimeParam(r, "to"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, model.ErrInvalidTimeRange
+	}
+	return &t, nil
+}
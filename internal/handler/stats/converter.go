@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+func ToAssignmentStatsResponse(report *model.AssignmentStatsReport) *AssignmentStatsResponse {
+	users := make([]UserAssignmentStatsDTO, 0, len(report.Users))
+	for _, s := range report.Users {
+		users = append(users, UserAssignmentStatsDTO{
+			UserID:             s.UserID,
+			Username:           s.Username,
+			TeamName:           s.TeamName,
+			AssignmentStatsDTO: toAssignmentStatsDTO(s.AssignmentStats),
+		})
+	}
+
+	teams := make([]TeamAssignmentStatsDTO, 0, len(report.Teams))
+	for _, s := range report.Teams {
+		teams = append(teams, TeamAssignmentStatsDTO{
+			TeamName:           s.TeamName,
+			AssignmentStatsDTO: toAssignmentStatsDTO(s.AssignmentStats),
+		})
+	}
+
+	return &AssignmentStatsResponse{
+		Users: users,
+		Teams: teams,
+	}
+}
+
+func toAssignmentStatsDTO(stats model.AssignmentStats) AssignmentStatsDTO {
+	return AssignmentStatsDTO{
+		Total:      stats.Total,
+		Open:       stats.Open,
+		Merged:     stats.Merged,
+		Reassigned: stats.Reassigned,
+	}
+}
+
+func ToPullRequestStatsResponse(report *model.PullRequestStatsReport) *PullRequestStatsResponse {
+	teams := make([]TeamPullRequestStatsDTO, 0, len(report.Teams))
+	for _, s := range report.Teams {
+		teams = append(teams, TeamPullRequestStatsDTO{
+			TeamName:            s.TeamName,
+			PullRequestStatsDTO: toPullRequestStatsDTO(s.PullRequestStats),
+		})
+	}
+
+	authors := make([]AuthorPullRequestStatsDTO, 0, len(report.Authors))
+	for _, s := range report.Authors {
+		authors = append(authors, AuthorPullRequestStatsDTO{
+			AuthorID:            s.AuthorID,
+			TeamName:            s.TeamName,
+			PullRequestStatsDTO: toPullRequestStatsDTO(s.PullRequestStats),
+		})
+	}
+
+	return &PullRequestStatsResponse{
+		OpenThresholdSeconds:         report.OpenThreshold.Seconds(),
+		OpenLongerThanThresholdTotal: report.StaleOpenTotal,
+		Teams:                        teams,
+		Authors:                      authors,
+	}
+}
+
+func toPullRequestStatsDTO(stats model.PullRequestStats) PullRequestStatsDTO {
+	return PullRequestStatsDTO{
+		MergedCount:              stats.MergedCount,
+		MedianTimeToMergeSeconds: toSeconds(stats.MedianTimeToMerge),
+		P90TimeToMergeSeconds:    toSeconds(stats.P90TimeToMerge),
+		OpenLongerThanThreshold:  stats.StaleOpenCount,
+	}
+}
+
+func toSeconds(d *time.Duration) *float64 {
+	if d == nil {
+		return nil
+	}
+	seconds := d.Seconds()
+	return &seconds
+}
@@ -0,0 +1,50 @@
+package stats
+
+type AssignmentStatsDTO struct {
+	Total      int `json:"total"`
+	Open       int `json:"open"`
+	Merged     int `json:"merged"`
+	Reassigned int `json:"reassigned"`
+}
+
+type UserAssignmentStatsDTO struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	AssignmentStatsDTO
+}
+
+type TeamAssignmentStatsDTO struct {
+	TeamName string `json:"team_name"`
+	AssignmentStatsDTO
+}
+
+type AssignmentStatsResponse struct {
+	Users []UserAssignmentStatsDTO `json:"users"`
+	Teams []TeamAssignmentStatsDTO `json:"teams"`
+}
+
+type PullRequestStatsDTO struct {
+	MergedCount              int      `json:"merged_count"`
+	MedianTimeToMergeSeconds *float64 `json:"median_time_to_merge_seconds"`
+	P90TimeToMergeSeconds    *float64 `json:"p90_time_to_merge_seconds"`
+	OpenLongerThanThreshold  int      `json:"open_longer_than_threshold"`
+}
+
+type TeamPullRequestStatsDTO struct {
+	TeamName string `json:"team_name"`
+	PullRequestStatsDTO
+}
+
+type AuthorPullRequestStatsDTO struct {
+	AuthorID string `json:"author_id"`
+	TeamName string `json:"team_name"`
+	PullRequestStatsDTO
+}
+
+type PullRequestStatsResponse struct {
+	OpenThresholdSeconds         float64                     `json:"open_threshold_seconds"`
+	OpenLongerThanThresholdTotal int                         `json:"open_longer_than_threshold_total"`
+	Teams                        []TeamPullRequestStatsDTO   `json:"teams"`
+	Authors                      []AuthorPullRequestStatsDTO `json:"authors"`
+}
@@ -0,0 +1,12 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type StatsService interface {
+	GetAssignmentStats(ctx context.Context, filter model.StatsFilter) (*model.AssignmentStatsReport, error)
+	GetPullRequestStats(ctx context.Context, filter model.PullRequestStatsFilter) (*model.PullRequestStatsReport, error)
+}
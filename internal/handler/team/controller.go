@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/avito/internship/pr-service/internal/handler"
+	prHandler "github.com/avito/internship/pr-service/internal/handler/pullrequest"
 	"github.com/avito/internship/pr-service/internal/model"
 )
 
@@ -42,3 +43,35 @@ func (c *TeamController) GetTeamByName(w http.ResponseWriter, r *http.Request) e
 	handler.WriteJSONResponse(w, http.StatusOK, ModelToDTO(team))
 	return nil
 }
+
+func (c *TeamController) SetSettings(w http.ResponseWriter, r *http.Request) error {
+	var req SetTeamSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+	team, err := c.teamService.UpdateSettings(r.Context(), req.TeamName, req.Settings.ToModel())
+	if err != nil {
+		return err
+	}
+	response := map[string]any{"team": ModelToDTO(team)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func (c *TeamController) DeactivateUsers(w http.ResponseWriter, r *http.Request) error {
+	var req DeactivateUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+	deactivation, err := c.teamService.DeactivateUsers(r.Context(), req.TeamName, req.UserIDs)
+	if err != nil {
+		return err
+	}
+	response := map[string]any{
+		"team_name":         deactivation.TeamName,
+		"deactivated_users": ToTeamMembers(deactivation.Users),
+		"reassignment":      prHandler.ToReassignmentReportDTO(deactivation.Reassignment),
+	}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
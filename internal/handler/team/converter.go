@@ -18,12 +18,63 @@ func (dto *TeamDTO) ToModel() *model.Team {
 	return &model.Team{
 		TeamName: dto.TeamName,
 		Users:    users,
+		Settings: dto.Settings.ToModel(),
+	}
+}
+
+func (dto *TeamSettingsDTO) ToModel() model.TeamSettings {
+	return model.TeamSettings{
+		ReviewerStrategy: dto.ReviewerStrategy,
+		MinReviewers:     dto.MinReviewers,
+		MaxReviewers:     dto.MaxReviewers,
+		MergePolicy: model.MergePolicy{
+			MinApprovals:            dto.MergePolicy.MinApprovals,
+			BlockOnChangesRequested: dto.MergePolicy.BlockOnChangesRequested,
+			AuthorCannotMerge:       dto.MergePolicy.AuthorCannotMerge,
+		},
+		ReviewSLAHours: dto.ReviewSLAHours,
+	}
+}
+
+func (dto *TeamSettingsUpdateDTO) ToModel() model.TeamSettingsUpdate {
+	return model.TeamSettingsUpdate{
+		ReviewerStrategy: dto.ReviewerStrategy,
+		MinReviewers:     dto.MinReviewers,
+		MaxReviewers:     dto.MaxReviewers,
+		MergePolicy: model.MergePolicyUpdate{
+			MinApprovals:            dto.MergePolicy.MinApprovals,
+			BlockOnChangesRequested: dto.MergePolicy.BlockOnChangesRequested,
+			AuthorCannotMerge:       dto.MergePolicy.AuthorCannotMerge,
+		},
+		ReviewSLAHours: dto.ReviewSLAHours,
+	}
+}
+
+func SettingsToDTO(settings model.TeamSettings) TeamSettingsDTO {
+	return TeamSettingsDTO{
+		ReviewerStrategy: settings.ReviewerStrategy,
+		MinReviewers:     settings.MinReviewers,
+		MaxReviewers:     settings.MaxReviewers,
+		MergePolicy: MergePolicyDTO{
+			MinApprovals:            settings.MergePolicy.MinApprovals,
+			BlockOnChangesRequested: settings.MergePolicy.BlockOnChangesRequested,
+			AuthorCannotMerge:       settings.MergePolicy.AuthorCannotMerge,
+		},
+		ReviewSLAHours: settings.ReviewSLAHours,
 	}
 }
 
 func ModelToDTO(team *model.Team) *TeamDTO {
+	return &TeamDTO{
+		TeamName: team.TeamName,
+		Members:  ToTeamMembers(team.Users),
+		Settings: SettingsToDTO(team.Settings),
+	}
+}
+
+func ToTeamMembers(users []model.User) []TeamMember {
 	var members []TeamMember
-	for _, user := range team.Users {
+	for _, user := range users {
 		member := TeamMember{
 			UserID:   user.UserID,
 			Username: user.Username,
@@ -31,8 +82,5 @@ func ModelToDTO(team *model.Team) *TeamDTO {
 		}
 		members = append(members, member)
 	}
-	return &TeamDTO{
-		TeamName: team.TeamName,
-		Members:  members,
-	}
+	return members
 }
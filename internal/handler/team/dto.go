@@ -1,12 +1,53 @@
 package team
 
+import "github.com/avito/internship/pr-service/internal/model"
+
 type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
 }
 
+type TeamSettingsDTO struct {
+	ReviewerStrategy model.ReviewerStrategy `json:"reviewer_strategy"`
+	MinReviewers     int                    `json:"min_reviewers"`
+	MaxReviewers     int                    `json:"max_reviewers"`
+	MergePolicy      MergePolicyDTO         `json:"merge_policy"`
+	ReviewSLAHours   int                    `json:"review_sla_hours"`
+}
+
+type MergePolicyDTO struct {
+	MinApprovals            int  `json:"min_approvals"`
+	BlockOnChangesRequested bool `json:"block_on_changes_requested"`
+	AuthorCannotMerge       bool `json:"author_cannot_merge"`
+}
+
 type TeamDTO struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName string          `json:"team_name"`
+	Members  []TeamMember    `json:"members"`
+	Settings TeamSettingsDTO `json:"settings"`
+}
+
+type TeamSettingsUpdateDTO struct {
+	ReviewerStrategy *model.ReviewerStrategy `json:"reviewer_strategy"`
+	MinReviewers     *int                    `json:"min_reviewers"`
+	MaxReviewers     *int                    `json:"max_reviewers"`
+	MergePolicy      MergePolicyUpdateDTO    `json:"merge_policy"`
+	ReviewSLAHours   *int                    `json:"review_sla_hours"`
+}
+
+type MergePolicyUpdateDTO struct {
+	MinApprovals            *int  `json:"min_approvals"`
+	BlockOnChangesRequested *bool `json:"block_on_changes_requested"`
+	AuthorCannotMerge       *bool `json:"author_cannot_merge"`
+}
+
+type SetTeamSettingsRequest struct {
+	TeamName string                `json:"team_name"`
+	Settings TeamSettingsUpdateDTO `json:"settings"`
+}
+
+type DeactivateUsersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
 }
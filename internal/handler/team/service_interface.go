@@ -9,4 +9,6 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error)
 	GetTeamByName(ctx context.Context, teamName string) (*model.Team, error)
+	UpdateSettings(ctx context.Context, teamName string, update model.TeamSettingsUpdate) (*model.Team, error)
+	DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*model.TeamDeactivation, error)
 }
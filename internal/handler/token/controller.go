@@ -0,0 +1,60 @@
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/avito/internship/pr-service/internal/handler"
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type TokenController struct {
+	authService AuthService
+}
+
+func NewTokenController(authService AuthService) *TokenController {
+	return &TokenController{authService: authService}
+}
+
+func (c *TokenController) Issue(w http.ResponseWriter, r *http.Request) error {
+	var req IssueTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	issued, err := c.authService.IssueToken(r.Context(), req.ToModel())
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusCreated, IssuedTokenDTO{
+		Token:  ToTokenDTO(issued.Token),
+		Secret: issued.Secret,
+	})
+	return nil
+}
+
+func (c *TokenController) List(w http.ResponseWriter, r *http.Request) error {
+	tokens, err := c.authService.ListTokens(r.Context())
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"tokens": ToTokenDTOs(tokens)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func (c *TokenController) Revoke(w http.ResponseWriter, r *http.Request) error {
+	var req RevokeTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	if err := c.authService.RevokeToken(r.Context(), req.TokenID); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,33 @@
+package token
+
+import "github.com/avito/internship/pr-service/internal/model"
+
+func (r *IssueTokenRequest) ToModel() *model.APIToken {
+	return &model.APIToken{
+		Name:      r.Name,
+		UserID:    r.UserID,
+		Role:      r.Role,
+		ExpiresAt: r.ExpiresAt,
+	}
+}
+
+func ToTokenDTO(token *model.APIToken) TokenDTO {
+	return TokenDTO{
+		ID:        token.ID,
+		Name:      token.Name,
+		UserID:    token.UserID,
+		Role:      token.Role,
+		TenantID:  token.TenantID,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+	}
+}
+
+func ToTokenDTOs(tokens []model.APIToken) []TokenDTO {
+	dtos := make([]TokenDTO, 0, len(tokens))
+	for i := range tokens {
+		dtos = append(dtos, ToTokenDTO(&tokens[i]))
+	}
+	return dtos
+}
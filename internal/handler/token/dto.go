@@ -0,0 +1,34 @@
+package token
+
+import (
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type TokenDTO struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	UserID    string     `json:"user_id,omitempty"`
+	Role      model.Role `json:"role"`
+	TenantID  string     `json:"tenant_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type IssuedTokenDTO struct {
+	Token  TokenDTO `json:"token"`
+	Secret string   `json:"secret"`
+}
+
+type IssueTokenRequest struct {
+	Name      string     `json:"name"`
+	UserID    string     `json:"user_id"`
+	Role      model.Role `json:"role"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type RevokeTokenRequest struct {
+	TokenID int64 `json:"token_id"`
+}
@@ -0,0 +1,13 @@
+package token
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type AuthService interface {
+	IssueToken(ctx context.Context, token *model.APIToken) (*model.IssuedToken, error)
+	ListTokens(ctx context.Context) ([]model.APIToken, error)
+	RevokeToken(ctx context.Context, id int64) error
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/avito/internship/pr-service/internal/handler"
+	prHandler "github.com/avito/internship/pr-service/internal/handler/pullrequest"
 	"github.com/avito/internship/pr-service/internal/model"
 )
 
@@ -22,11 +23,14 @@ func (controller *UserController) SetIsActive(w http.ResponseWriter, r *http.Req
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return model.ErrBadJSONRequest
 	}
-	user, err := controller.userService.SetActiveStatus(r.Context(), req.UserID, req.IsActive)
+	change, err := controller.userService.SetActiveStatus(r.Context(), req.UserID, req.IsActive)
 	if err != nil {
 		return err
 	}
-	response := map[string]any{"user": ToUserResponse(user)}
+	response := map[string]any{"user": ToUserResponse(change.User)}
+	if change.Reassignment != nil {
+		response["reassignment"] = prHandler.ToReassignmentReportDTO(change.Reassignment)
+	}
 	handler.WriteJSONResponse(w, http.StatusOK, response)
 	return nil
 }
@@ -7,7 +7,7 @@ import (
 )
 
 type UserService interface {
-	SetActiveStatus(ctx context.Context, userId string, isActive bool) (*model.User, error)
+	SetActiveStatus(ctx context.Context, userId string, isActive bool) (*model.UserStatusChange, error)
 	GetUserByID(ctx context.Context, userId string) (*model.User, error)
 }
 
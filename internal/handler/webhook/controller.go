@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/avito/internship/pr-service/internal/handler"
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type WebhookController struct {
+	webhookService WebhookService
+}
+
+func NewWebhookController(webhookService WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
+
+func (c *WebhookController) Create(w http.ResponseWriter, r *http.Request) error {
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	sub, err := c.webhookService.CreateSubscription(r.Context(), req.ToModel())
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"webhook": ToWebhookSubscriptionDTO(sub, true)}
+	handler.WriteJSONResponse(w, http.StatusCreated, response)
+	return nil
+}
+
+func (c *WebhookController) Get(w http.ResponseWriter, r *http.Request) error {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		return model.ErrInvalidWebhookID
+	}
+
+	sub, err := c.webhookService.GetSubscription(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	handler.WriteJSONResponse(w, http.StatusOK, ToWebhookSubscriptionDTO(sub, false))
+	return nil
+}
+
+func (c *WebhookController) List(w http.ResponseWriter, r *http.Request) error {
+	subs, err := c.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"webhooks": ToWebhookSubscriptionDTOs(subs)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func (c *WebhookController) Update(w http.ResponseWriter, r *http.Request) error {
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	current, err := c.webhookService.GetSubscription(r.Context(), req.ID)
+	if err != nil {
+		return err
+	}
+
+	sub, err := c.webhookService.UpdateSubscription(r.Context(), req.ToModel(current))
+	if err != nil {
+		return err
+	}
+
+	response := map[string]any{"webhook": ToWebhookSubscriptionDTO(sub, false)}
+	handler.WriteJSONResponse(w, http.StatusOK, response)
+	return nil
+}
+
+func (c *WebhookController) Delete(w http.ResponseWriter, r *http.Request) error {
+	var req DeleteWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return model.ErrBadJSONRequest
+	}
+
+	if err := c.webhookService.DeleteSubscription(r.Context(), req.ID); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import "github.com/avito/internship/pr-service/internal/model"
+
+func (req *CreateWebhookRequest) ToModel() *model.WebhookSubscription {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	return &model.WebhookSubscription{
+		URL:      req.URL,
+		Secret:   req.Secret,
+		Events:   req.Events,
+		IsActive: isActive,
+	}
+}
+
+func (req *UpdateWebhookRequest) ToModel(current *model.WebhookSubscription) *model.WebhookSubscription {
+	isActive := current.IsActive
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	return &model.WebhookSubscription{
+		ID:       req.ID,
+		URL:      req.URL,
+		Secret:   req.Secret,
+		Events:   req.Events,
+		IsActive: isActive,
+	}
+}
+
+func ToWebhookSubscriptionDTO(sub *model.WebhookSubscription, withSecret bool) *WebhookSubscriptionDTO {
+	dto := &WebhookSubscriptionDTO{
+		ID:        sub.ID,
+		URL:       sub.URL,
+		Events:    sub.Events,
+		IsActive:  sub.IsActive,
+		CreatedAt: sub.CreatedAt,
+	}
+	if withSecret {
+		dto.Secret = sub.Secret
+	}
+	return dto
+}
+
+func ToWebhookSubscriptionDTOs(subs []model.WebhookSubscription) []WebhookSubscriptionDTO {
+	dtos := make([]WebhookSubscriptionDTO, 0, len(subs))
+	for i := range subs {
+		dtos = append(dtos, *ToWebhookSubscriptionDTO(&subs[i], false))
+	}
+	return dtos
+}
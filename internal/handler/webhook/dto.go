@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type WebhookSubscriptionDTO struct {
+	ID        int64             `json:"id"`
+	URL       string            `json:"url"`
+	Secret    string            `json:"secret,omitempty"`
+	Events    []model.EventType `json:"events"`
+	IsActive  bool              `json:"is_active"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type CreateWebhookRequest struct {
+	URL      string            `json:"url"`
+	Secret   string            `json:"secret"`
+	Events   []model.EventType `json:"events"`
+	IsActive *bool             `json:"is_active"`
+}
+
+type UpdateWebhookRequest struct {
+	ID       int64             `json:"id"`
+	URL      string            `json:"url"`
+	Secret   string            `json:"secret"`
+	Events   []model.EventType `json:"events"`
+	IsActive *bool             `json:"is_active"`
+}
+
+type DeleteWebhookRequest struct {
+	ID int64 `json:"id"`
+}
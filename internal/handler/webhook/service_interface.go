@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_service"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	PullRequestsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_created_total",
+		Help:      "Number of created pull requests.",
+	})
+
+	PullRequestsMerged = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_merged_total",
+		Help:      "Number of merged pull requests.",
+	})
+
+	ReviewersReassigned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviewers_reassigned_total",
+		Help:      "Number of reviewer reassignments by operation.",
+	}, []string{"operation"})
+
+	NoCandidateFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "no_candidate_failures_total",
+		Help:      "Number of NO_CANDIDATE failures by operation.",
+	}, []string{"operation"})
+)
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := "unknown"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		labels := prometheus.Labels{"method": r.Method, "route": route, "status": strconv.Itoa(status)}
+
+		httpRequests.With(labels).Inc()
+		httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+func RegisterPoolStats(pool *pgxpool.Pool) {
+	gauges := map[string]func(stat *pgxpool.Stat) float64{
+		"db_pool_acquired_connections": func(stat *pgxpool.Stat) float64 { return float64(stat.AcquiredConns()) },
+		"db_pool_idle_connections":     func(stat *pgxpool.Stat) float64 { return float64(stat.IdleConns()) },
+		"db_pool_total_connections":    func(stat *pgxpool.Stat) float64 { return float64(stat.TotalConns()) },
+		"db_pool_max_connections":      func(stat *pgxpool.Stat) float64 { return float64(stat.MaxConns()) },
+	}
+	for name, value := range gauges {
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      name,
+			Help:      "pgxpool " + name + ".",
+		}, func() float64 {
+			return value(pool.Stat())
+		})
+	}
+}
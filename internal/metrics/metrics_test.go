@@ -0,0 +1,78 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avito/internship/pr-service/internal/metrics"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(metrics.Middleware)
+	r.Get("/metrics-test/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	r.Post("/metrics-test/implicit", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	r.Route("/metrics-test/nested", func(r chi.Router) {
+		r.Get("/{name}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	})
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedLine string
+	}{
+		{
+			name:         "Route pattern instead of raw path",
+			method:       http.MethodGet,
+			path:         "/metrics-test/items/42",
+			expectedLine: `pr_service_http_requests_total{method="GET",route="/metrics-test/items/{id}",status="201"} 1`,
+		},
+		{
+			name:         "Implicit status is 200",
+			method:       http.MethodPost,
+			path:         "/metrics-test/implicit",
+			expectedLine: `pr_service_http_requests_total{method="POST",route="/metrics-test/implicit",status="200"} 1`,
+		},
+		{
+			name:         "Nested router pattern is joined",
+			method:       http.MethodGet,
+			path:         "/metrics-test/nested/abc",
+			expectedLine: `pr_service_http_requests_total{method="GET",route="/metrics-test/nested/{name}",status="404"} 1`,
+		},
+		{
+			name:         "Unmatched path is not used as a label",
+			method:       http.MethodGet,
+			path:         "/metrics-test/missing/123",
+			expectedLine: `pr_service_http_requests_total{method="GET",route="unknown",status="404"} 1`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(test.method, test.path, nil))
+
+			assert.Contains(t, scrape(t), test.expectedLine)
+		})
+	}
+}
+
+func scrape(t *testing.T) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
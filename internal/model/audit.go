@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionCreate     AuditAction = "create"
+	AuditActionMerge      AuditAction = "merge"
+	AuditActionReassign   AuditAction = "reassign"
+	AuditActionSetActive  AuditAction = "set_active"
+	AuditActionTeamCreate AuditAction = "team_create"
+	AuditActionClose      AuditAction = "close"
+	AuditActionReopen     AuditAction = "reopen"
+	AuditActionMarkReady  AuditAction = "mark_ready"
+	AuditActionSettings   AuditAction = "update_settings"
+)
+
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionCreate, AuditActionMerge, AuditActionReassign, AuditActionSetActive, AuditActionTeamCreate,
+		AuditActionClose, AuditActionReopen, AuditActionMarkReady, AuditActionSettings:
+		return true
+	}
+	return false
+}
+
+type AuditTarget string
+
+const (
+	AuditTargetPullRequest AuditTarget = "pull_request"
+	AuditTargetUser        AuditTarget = "user"
+	AuditTargetTeam        AuditTarget = "team"
+)
+
+type AuditEvent struct {
+	ID            int64
+	Actor         string
+	Action        AuditAction
+	TargetType    AuditTarget
+	TargetID      string
+	PullRequestID string
+	UserID        string
+	TeamName      string
+	Before        any
+	After         any
+	CreatedAt     time.Time
+}
+
+type AuditFilter struct {
+	PullRequestID string
+	UserID        string
+	TeamName      string
+	Action        AuditAction
+	From          *time.Time
+	To            *time.Time
+	Limit         int
+}
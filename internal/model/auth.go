@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleLead   Role = "lead"
+	RoleMember Role = "member"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleLead, RoleMember:
+		return true
+	}
+	return false
+}
+
+type Principal struct {
+	Subject  string
+	UserID   string
+	Role     Role
+	TokenID  int64
+	TenantID string
+}
+
+type APIToken struct {
+	ID        int64
+	Name      string
+	UserID    string
+	Role      Role
+	TenantID  string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
+
+type IssuedToken struct {
+	Token  *APIToken
+	Secret string
+}
@@ -1,5 +1,7 @@
 package model
 
+import "strings"
+
 type DomainError struct {
 	Code    string
 	Message string
@@ -13,11 +15,52 @@ var (
 	ErrTeamExists           = &DomainError{Code: "TEAM_EXISTS", Message: "team_name already exists"}
 	ErrTeamNotFound         = &DomainError{Code: "NOT_FOUND", Message: "team not found"}
 	ErrUserNotFound         = &DomainError{Code: "NOT_FOUND", Message: "user not found"}
+	ErrUserNotInTeam        = &DomainError{Code: "NOT_FOUND", Message: "user not found in team"}
 	ErrPullRequestNotFound  = &DomainError{Code: "NOT_FOUND", Message: "pull request not found"}
+	ErrWebhookNotFound      = &DomainError{Code: "NOT_FOUND", Message: "webhook subscription not found"}
+	ErrIdentityNotFound     = &DomainError{Code: "NOT_FOUND", Message: "external identity not found"}
+	ErrTokenNotFound        = &DomainError{Code: "NOT_FOUND", Message: "api token not found"}
 	ErrPullRequestExists    = &DomainError{Code: "PR_EXISTS", Message: "pull request already exists"}
 	ErrPRMerged             = &DomainError{Code: "PR_MERGED", Message: "cannot reassign on merged PR"}
+	ErrPRMergedNoClose      = &DomainError{Code: "PR_MERGED", Message: "cannot close or reopen merged PR"}
+	ErrPRMergedNoReview     = &DomainError{Code: "PR_MERGED", Message: "cannot review merged PR"}
+	ErrPRClosed             = &DomainError{Code: "PR_CLOSED", Message: "pull request is closed"}
+	ErrPRDraft              = &DomainError{Code: "PR_DRAFT", Message: "pull request is a draft"}
+	ErrMergeBlocked         = &DomainError{Code: "MERGE_BLOCKED", Message: "merge policy is not satisfied"}
 	ErrReviewerNotAssigned  = &DomainError{Code: "NOT_ASSIGNED", Message: "reviewer is not assigned to this pull request"}
 	ErrNoReviewerCandidates = &DomainError{Code: "NO_CANDIDATE", Message: "no active replacement candidate in team"}
+	ErrNotEnoughReviewers   = &DomainError{Code: "NO_CANDIDATE", Message: "not enough active reviewer candidates in team"}
 	ErrBadJSONRequest       = &DomainError{Code: "BAD_REQUEST", Message: "bad json request"}
 	ErrTeamNoUsers          = &DomainError{Code: "BAD_REQUEST", Message: "team must have at least one user"}
+	ErrNoUsersToDeactivate  = &DomainError{Code: "BAD_REQUEST", Message: "user_ids must not be empty"}
+	ErrInvalidTimeRange     = &DomainError{Code: "BAD_REQUEST", Message: "invalid time range"}
+	ErrInvalidThreshold     = &DomainError{Code: "BAD_REQUEST", Message: "invalid open threshold"}
+	ErrInvalidStrategy      = &DomainError{Code: "BAD_REQUEST", Message: "unknown reviewer strategy"}
+	ErrInvalidReviewerCount = &DomainError{Code: "BAD_REQUEST", Message: "invalid min/max reviewers count"}
+	ErrInvalidMergePolicy   = &DomainError{Code: "BAD_REQUEST", Message: "min_approvals must be between 0 and max_reviewers"}
+	ErrInvalidReviewSLA     = &DomainError{Code: "BAD_REQUEST", Message: "review_sla_hours must not be negative"}
+	ErrInvalidWebhookID     = &DomainError{Code: "BAD_REQUEST", Message: "invalid webhook id"}
+	ErrInvalidWebhookURL    = &DomainError{Code: "BAD_REQUEST", Message: "url must be an absolute http or https URL"}
+	ErrInvalidEventType     = &DomainError{Code: "BAD_REQUEST", Message: "unknown event type"}
+	ErrNoWebhookEvents      = &DomainError{Code: "BAD_REQUEST", Message: "events must not be empty"}
+	ErrInvalidReviewState   = &DomainError{Code: "BAD_REQUEST", Message: "state must be APPROVED or CHANGES_REQUESTED"}
+	ErrInvalidProvider      = &DomainError{Code: "BAD_REQUEST", Message: "unknown provider"}
+	ErrInvalidIdentity      = &DomainError{Code: "BAD_REQUEST", Message: "login and user_id are required"}
+	ErrBadWebhookPayload    = &DomainError{Code: "BAD_REQUEST", Message: "bad webhook payload"}
+	ErrInvalidAuditAction   = &DomainError{Code: "BAD_REQUEST", Message: "unknown audit action"}
+	ErrInvalidLimit         = &DomainError{Code: "BAD_REQUEST", Message: "invalid limit"}
+	ErrInvalidRole          = &DomainError{Code: "BAD_REQUEST", Message: "unknown role"}
+	ErrInvalidTokenRequest  = &DomainError{Code: "BAD_REQUEST", Message: "name is required and member tokens need a user_id"}
+	ErrUsernameTaken        = &DomainError{Code: "BAD_REQUEST", Message: "username is already taken"}
+	ErrInvalidSignature     = &DomainError{Code: "UNAUTHORIZED", Message: "invalid webhook signature"}
+	ErrUnauthenticated      = &DomainError{Code: "UNAUTHORIZED", Message: "missing or invalid bearer token"}
+	ErrForbidden            = &DomainError{Code: "FORBIDDEN", Message: "operation is not allowed for this principal"}
+	ErrTenantRequired       = &DomainError{Code: "FORBIDDEN", Message: "principal is not bound to a tenant"}
 )
+
+func NewMergeBlockedError(conditions []string) *DomainError {
+	return &DomainError{
+		Code:    ErrMergeBlocked.Code,
+		Message: ErrMergeBlocked.Message + ": " + strings.Join(conditions, "; "),
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+type EventType string
+
+const (
+	EventPullRequestCreated EventType = "pull_request.created"
+	EventReviewersAssigned  EventType = "pull_request.reviewers_assigned"
+	EventReviewerReassigned EventType = "pull_request.reassigned"
+	EventPullRequestMerged  EventType = "pull_request.merged"
+)
+
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventPullRequestCreated, EventReviewersAssigned, EventReviewerReassigned, EventPullRequestMerged:
+		return true
+	}
+	return false
+}
+
+type Event struct {
+	Type         EventType
+	OccurredAt   time.Time
+	PullRequest  *PullRequest
+	Reviewers    []string
+	Reassignment *ReviewReassignment
+}
+
+type OutboxEvent struct {
+	ID             int64
+	TenantID       string
+	Type           EventType
+	Payload        []byte
+	Attempts       int
+	DeliveredSinks []string
+	CreatedAt      time.Time
+}
+
+type WebhookSubscription struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []EventType
+	IsActive  bool
+	CreatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	URL            string
+	Secret         string
+	EventType      EventType
+	Payload        []byte
+	Attempts       int
+}
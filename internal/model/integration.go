@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+type ExternalProvider string
+
+const (
+	ProviderGitHub ExternalProvider = "github"
+	ProviderGitLab ExternalProvider = "gitlab"
+)
+
+func (p ExternalProvider) IsValid() bool {
+	switch p {
+	case ProviderGitHub, ProviderGitLab:
+		return true
+	}
+	return false
+}
+
+type ExternalIdentity struct {
+	Provider      ExternalProvider
+	ExternalLogin string
+	UserID        string
+	CreatedAt     time.Time
+}
+
+type ExternalAction string
+
+const (
+	ExternalActionOpened         ExternalAction = "opened"
+	ExternalActionReadyForReview ExternalAction = "ready_for_review"
+	ExternalActionMerged         ExternalAction = "merged"
+	ExternalActionClosed         ExternalAction = "closed"
+	ExternalActionReopened       ExternalAction = "reopened"
+)
+
+type ExternalPullRequestEvent struct {
+	Provider      ExternalProvider
+	Action        ExternalAction
+	PullRequestID string
+	Title         string
+	AuthorLogin   string
+	MergedByLogin string
+	IsDraft       bool
+	Payload       []byte
+}
+
+type IntegrationStatus string
+
+const (
+	IntegrationProcessed IntegrationStatus = "processed"
+	IntegrationIgnored   IntegrationStatus = "ignored"
+	IntegrationRejected  IntegrationStatus = "rejected"
+)
+
+type IntegrationResult struct {
+	Status      IntegrationStatus
+	Action      ExternalAction
+	PullRequest *PullRequest
+	Rejection   *IntegrationRejection
+}
+
+type IntegrationRejection struct {
+	ID            int64
+	Provider      ExternalProvider
+	Action        ExternalAction
+	PullRequestID string
+	ExternalLogin string
+	Reason        string
+	Payload       []byte
+	CreatedAt     time.Time
+}
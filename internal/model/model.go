@@ -14,6 +14,83 @@ type User struct {
 type Team struct {
 	TeamName string
 	Users    []User
+	Settings TeamSettings
+}
+
+type TeamSettings struct {
+	ReviewerStrategy ReviewerStrategy
+	MinReviewers     int
+	MaxReviewers     int
+	MergePolicy      MergePolicy
+	ReviewSLAHours   int
+}
+
+type MergePolicy struct {
+	MinApprovals            int
+	BlockOnChangesRequested bool
+	AuthorCannotMerge       bool
+}
+
+type TeamSettingsUpdate struct {
+	ReviewerStrategy *ReviewerStrategy
+	MinReviewers     *int
+	MaxReviewers     *int
+	MergePolicy      MergePolicyUpdate
+	ReviewSLAHours   *int
+}
+
+type MergePolicyUpdate struct {
+	MinApprovals            *int
+	BlockOnChangesRequested *bool
+	AuthorCannotMerge       *bool
+}
+
+func (u TeamSettingsUpdate) Apply(settings TeamSettings) TeamSettings {
+	if u.ReviewerStrategy != nil {
+		settings.ReviewerStrategy = *u.ReviewerStrategy
+	}
+	if u.MinReviewers != nil {
+		settings.MinReviewers = *u.MinReviewers
+	}
+	if u.MaxReviewers != nil {
+		settings.MaxReviewers = *u.MaxReviewers
+	}
+	if u.MergePolicy.MinApprovals != nil {
+		settings.MergePolicy.MinApprovals = *u.MergePolicy.MinApprovals
+	}
+	if u.MergePolicy.BlockOnChangesRequested != nil {
+		settings.MergePolicy.BlockOnChangesRequested = *u.MergePolicy.BlockOnChangesRequested
+	}
+	if u.MergePolicy.AuthorCannotMerge != nil {
+		settings.MergePolicy.AuthorCannotMerge = *u.MergePolicy.AuthorCannotMerge
+	}
+	if u.ReviewSLAHours != nil {
+		settings.ReviewSLAHours = *u.ReviewSLAHours
+	}
+	return settings
+}
+
+const (
+	DefaultMinReviewers = 0
+	DefaultMaxReviewers = 2
+	ReviewersLimit      = 10
+)
+
+type ReviewerStrategy string
+
+const (
+	ReviewerStrategyRandom      ReviewerStrategy = "RANDOM"
+	ReviewerStrategyRoundRobin  ReviewerStrategy = "ROUND_ROBIN"
+	ReviewerStrategyLeastLoaded ReviewerStrategy = "LEAST_LOADED"
+	ReviewerStrategyWeighted    ReviewerStrategy = "WEIGHTED"
+)
+
+func (s ReviewerStrategy) IsValid() bool {
+	switch s {
+	case ReviewerStrategyRandom, ReviewerStrategyRoundRobin, ReviewerStrategyLeastLoaded, ReviewerStrategyWeighted:
+		return true
+	}
+	return false
 }
 
 type PullRequest struct {
@@ -22,8 +99,17 @@ type PullRequest struct {
 	AuthorID          string
 	Status            PullRequestStatus
 	AssignedReviewers []string
+	Reviews           []Review
 	CreatedAt         time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
+	MergedBy          *string
+	MergeOverride     bool
+}
+
+type MergeOptions struct {
+	MergedBy string
+	Override bool
 }
 
 type PullRequestStatus string
@@ -31,9 +117,99 @@ type PullRequestStatus string
 const (
 	PullRequestMerged PullRequestStatus = "MERGED"
 	PullRequestOpen   PullRequestStatus = "OPEN"
+	PullRequestClosed PullRequestStatus = "CLOSED"
+	PullRequestDraft  PullRequestStatus = "DRAFT"
+)
+
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
 )
 
+func (s ReviewState) IsVerdict() bool {
+	return s == ReviewStateApproved || s == ReviewStateChangesRequested
+}
+
+type Review struct {
+	ReviewerID string
+	State      ReviewState
+	AssignedAt time.Time
+	DueAt      *time.Time
+	ReviewedAt *time.Time
+}
+
+type AssignmentReason string
+
+const (
+	AssignmentReasonInitial      AssignmentReason = "initial"
+	AssignmentReasonReassign     AssignmentReason = "reassign"
+	AssignmentReasonDeactivation AssignmentReason = "deactivation"
+	AssignmentReasonEscalation   AssignmentReason = "escalation"
+)
+
+type ReviewerAssignment struct {
+	ReviewerID   string
+	Reason       AssignmentReason
+	State        ReviewState
+	AssignedAt   time.Time
+	UnassignedAt *time.Time
+	DueAt        *time.Time
+	ReviewedAt   *time.Time
+}
+
+type PullRequestHistory struct {
+	PullRequestID string
+	Assignments   []ReviewerAssignment
+}
+
+type OverdueReview struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	ReviewerID      string
+	AssignedAt      time.Time
+	DueAt           time.Time
+}
+
+type ReviewEscalation struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+	Reason        string
+}
+
 type ReassignResponse struct {
 	PullRequest *PullRequest
 	ReplacedBy  string
 }
+
+type ReviewReassignment struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+}
+
+type ReassignmentFailure struct {
+	PullRequestID string
+	ReviewerID    string
+	Reason        string
+}
+
+type ReassignmentReport struct {
+	Reassigned []ReviewReassignment
+	Failed     []ReassignmentFailure
+}
+
+type UserStatusChange struct {
+	User         *User
+	Reassignment *ReassignmentReport
+}
+
+type TeamDeactivation struct {
+	TeamName     string
+	Users        []User
+	Reassignment *ReassignmentReport
+}
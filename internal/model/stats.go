@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+type StatsFilter struct {
+	From *time.Time
+	To   *time.Time
+}
+
+type AssignmentStats struct {
+	Total      int
+	Open       int
+	Merged     int
+	Reassigned int
+}
+
+type UserAssignmentStats struct {
+	UserID   string
+	Username string
+	TeamName string
+	AssignmentStats
+}
+
+type TeamAssignmentStats struct {
+	TeamName string
+	AssignmentStats
+}
+
+type AssignmentStatsReport struct {
+	Users []UserAssignmentStats
+	Teams []TeamAssignmentStats
+}
+
+type PullRequestStatsFilter struct {
+	StatsFilter
+	OpenThreshold time.Duration
+}
+
+type PullRequestStats struct {
+	MergedCount       int
+	MedianTimeToMerge *time.Duration
+	P90TimeToMerge    *time.Duration
+	StaleOpenCount    int
+}
+
+type TeamPullRequestStats struct {
+	TeamName string
+	PullRequestStats
+}
+
+type AuthorPullRequestStats struct {
+	AuthorID string
+	TeamName string
+	PullRequestStats
+}
+
+type PullRequestStatsReport struct {
+	OpenThreshold  time.Duration
+	StaleOpenTotal int
+	Teams          []TeamPullRequestStats
+	Authors        []AuthorPullRequestStats
+}
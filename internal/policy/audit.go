@@ -0,0 +1,22 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type AuditPolicy struct {
+	auditService AuditService
+}
+
+func NewAuditPolicy(auditService AuditService) *AuditPolicy {
+	return &AuditPolicy{auditService: auditService}
+}
+
+func (p *AuditPolicy) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.auditService.List(ctx, filter)
+}
@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type IntegrationPolicy struct {
+	integrationService IntegrationService
+}
+
+func NewIntegrationPolicy(integrationService IntegrationService) *IntegrationPolicy {
+	return &IntegrationPolicy{integrationService: integrationService}
+}
+
+func (p *IntegrationPolicy) SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.integrationService.SaveIdentity(ctx, identity)
+}
+
+func (p *IntegrationPolicy) ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.integrationService.ListIdentities(ctx, provider)
+}
+
+func (p *IntegrationPolicy) DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	return p.integrationService.DeleteIdentity(ctx, provider, login)
+}
+
+func (p *IntegrationPolicy) ListRejections(ctx context.Context, provider model.ExternalProvider) ([]model.IntegrationRejection, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.integrationService.ListRejections(ctx, provider)
+}
+
+func (p *IntegrationPolicy) HandleGitHubWebhook(ctx context.Context, eventName, signature string, body []byte) (*model.IntegrationResult, error) {
+	return p.integrationService.HandleGitHubWebhook(ctx, eventName, signature, body)
+}
+
+func (p *IntegrationPolicy) HandleGitLabWebhook(ctx context.Context, token string, body []byte) (*model.IntegrationResult, error) {
+	return p.integrationService.HandleGitLabWebhook(ctx, token, body)
+}
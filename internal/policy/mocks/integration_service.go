@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type IntegrationService struct {
+	mock.Mock
+}
+
+func (m *IntegrationService) SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error) {
+	args := m.Called(ctx, identity)
+	var saved *model.ExternalIdentity
+	if args.Get(0) != nil {
+		saved = args.Get(0).(*model.ExternalIdentity)
+	}
+	return saved, args.Error(1)
+}
+
+func (m *IntegrationService) ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error) {
+	args := m.Called(ctx, provider)
+	var identities []model.ExternalIdentity
+	if args.Get(0) != nil {
+		identities = args.Get(0).([]model.ExternalIdentity)
+	}
+	return identities, args.Error(1)
+}
+
+func (m *IntegrationService) DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error {
+	args := m.Called(ctx, provider, login)
+	return args.Error(0)
+}
+
+func (m *IntegrationService) ListRejections(ctx context.Context, provider model.ExternalProvider) ([]model.IntegrationRejection, error) {
+	args := m.Called(ctx, provider)
+	var rejections []model.IntegrationRejection
+	if args.Get(0) != nil {
+		rejections = args.Get(0).([]model.IntegrationRejection)
+	}
+	return rejections, args.Error(1)
+}
+
+func (m *IntegrationService) HandleGitHubWebhook(ctx context.Context, eventName, signature string, body []byte) (*model.IntegrationResult, error) {
+	args := m.Called(ctx, eventName, signature, body)
+	return integrationResult(args)
+}
+
+func (m *IntegrationService) HandleGitLabWebhook(ctx context.Context, token string, body []byte) (*model.IntegrationResult, error) {
+	args := m.Called(ctx, token, body)
+	return integrationResult(args)
+}
+
+func integrationResult(args mock.Arguments) (*model.IntegrationResult, error) {
+	var result *model.IntegrationResult
+	if args.Get(0) != nil {
+		result = args.Get(0).(*model.IntegrationResult)
+	}
+	return result, args.Error(1)
+}
@@ -0,0 +1,82 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type PullRequestService struct {
+	mock.Mock
+}
+
+func (m *PullRequestService) Create(ctx context.Context, prID, prName, authorID string, isDraft bool) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID, prName, authorID, isDraft)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) MarkReady(ctx context.Context, prID string) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) Merge(ctx context.Context, prID string, opts model.MergeOptions) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID, opts)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) Close(ctx context.Context, prID string) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) Reopen(ctx context.Context, prID string) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) SubmitReview(ctx context.Context, prID, reviewerID string, state model.ReviewState) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID, reviewerID, state)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) GetOverdueReviews(ctx context.Context) ([]model.OverdueReview, error) {
+	args := m.Called(ctx)
+	var reviews []model.OverdueReview
+	if args.Get(0) != nil {
+		reviews = args.Get(0).([]model.OverdueReview)
+	}
+	return reviews, args.Error(1)
+}
+
+func (m *PullRequestService) GetHistory(ctx context.Context, prID string) (*model.PullRequestHistory, error) {
+	args := m.Called(ctx, prID)
+	var history *model.PullRequestHistory
+	if args.Get(0) != nil {
+		history = args.Get(0).(*model.PullRequestHistory)
+	}
+	return history, args.Error(1)
+}
+
+func (m *PullRequestService) GetPullRequest(ctx context.Context, prID string) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID string) (*model.ReassignResponse, error) {
+	args := m.Called(ctx, prID, oldReviewerID)
+	var response *model.ReassignResponse
+	if args.Get(0) != nil {
+		response = args.Get(0).(*model.ReassignResponse)
+	}
+	return response, args.Error(1)
+}
+
+func pullRequest(args mock.Arguments) (*model.PullRequest, error) {
+	var pr *model.PullRequest
+	if args.Get(0) != nil {
+		pr = args.Get(0).(*model.PullRequest)
+	}
+	return pr, args.Error(1)
+}
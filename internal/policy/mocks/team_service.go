@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type TeamService struct {
+	mock.Mock
+}
+
+func (m *TeamService) CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error) {
+	args := m.Called(ctx, team)
+	return teamResult(args)
+}
+
+func (m *TeamService) GetTeamByName(ctx context.Context, teamName string) (*model.Team, error) {
+	args := m.Called(ctx, teamName)
+	return teamResult(args)
+}
+
+func (m *TeamService) UpdateSettings(ctx context.Context, teamName string, update model.TeamSettingsUpdate) (*model.Team, error) {
+	args := m.Called(ctx, teamName, update)
+	return teamResult(args)
+}
+
+func (m *TeamService) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*model.TeamDeactivation, error) {
+	args := m.Called(ctx, teamName, userIDs)
+	var deactivation *model.TeamDeactivation
+	if args.Get(0) != nil {
+		deactivation = args.Get(0).(*model.TeamDeactivation)
+	}
+	return deactivation, args.Error(1)
+}
+
+func teamResult(args mock.Arguments) (*model.Team, error) {
+	var team *model.Team
+	if args.Get(0) != nil {
+		team = args.Get(0).(*model.Team)
+	}
+	return team, args.Error(1)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserService struct {
+	mock.Mock
+}
+
+func (m *UserService) SetActiveStatus(ctx context.Context, userId string, isActive bool) (*model.UserStatusChange, error) {
+	args := m.Called(ctx, userId, isActive)
+	var change *model.UserStatusChange
+	if args.Get(0) != nil {
+		change = args.Get(0).(*model.UserStatusChange)
+	}
+	return change, args.Error(1)
+}
+
+func (m *UserService) GetUserByID(ctx context.Context, userId string) (*model.User, error) {
+	args := m.Called(ctx, userId)
+	var user *model.User
+	if args.Get(0) != nil {
+		user = args.Get(0).(*model.User)
+	}
+	return user, args.Error(1)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookService struct {
+	mock.Mock
+}
+
+func (m *WebhookService) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, sub)
+	return subscription(args)
+}
+
+func (m *WebhookService) GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	return subscription(args)
+}
+
+func (m *WebhookService) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	var subs []model.WebhookSubscription
+	if args.Get(0) != nil {
+		subs = args.Get(0).([]model.WebhookSubscription)
+	}
+	return subs, args.Error(1)
+}
+
+func (m *WebhookService) UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, sub)
+	return subscription(args)
+}
+
+func (m *WebhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func subscription(args mock.Arguments) (*model.WebhookSubscription, error) {
+	var sub *model.WebhookSubscription
+	if args.Get(0) != nil {
+		sub = args.Get(0).(*model.WebhookSubscription)
+	}
+	return sub, args.Error(1)
+}
@@ -0,0 +1,92 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
+)
+
+type authorizer struct {
+	users UserGetter
+}
+
+func currentPrincipal(ctx context.Context) (*model.Principal, error) {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+	return p, nil
+}
+
+func requireAdmin(ctx context.Context) error {
+	p, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	if p.Role != model.RoleAdmin {
+		return model.ErrForbidden
+	}
+	return nil
+}
+
+func bindCaller(ctx context.Context, userID string) (string, error) {
+	caller, err := currentPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	if caller.Role == model.RoleAdmin {
+		return userID, nil
+	}
+	if caller.UserID == "" || (userID != "" && userID != caller.UserID) {
+		return "", model.ErrForbidden
+	}
+	return caller.UserID, nil
+}
+
+func (a authorizer) requireTeamManager(ctx context.Context, teamName string) error {
+	p, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	if p.Role == model.RoleAdmin {
+		return nil
+	}
+	if p.Role != model.RoleLead {
+		return model.ErrForbidden
+	}
+
+	leadTeam, err := a.principalTeam(ctx, p)
+	if err != nil {
+		return err
+	}
+	if leadTeam != teamName {
+		return model.ErrForbidden
+	}
+	return nil
+}
+
+func (a authorizer) principalTeam(ctx context.Context, p *model.Principal) (string, error) {
+	if p.UserID == "" {
+		return "", model.ErrForbidden
+	}
+
+	user, err := a.users.GetUserByID(ctx, p.UserID)
+	if errors.Is(err, model.ErrUserNotFound) {
+		return "", model.ErrForbidden
+	}
+	if err != nil {
+		return "", fmt.Errorf("get principal user: %w", err)
+	}
+	return user.TeamName, nil
+}
+
+func (a authorizer) userTeam(ctx context.Context, userID string) (string, error) {
+	user, err := a.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("get user: %w", err)
+	}
+	return user.TeamName, nil
+}
@@ -0,0 +1,728 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/policy"
+	"github.com/avito/internship/pr-service/internal/policy/mocks"
+	"github.com/avito/internship/pr-service/internal/principal"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	adminCtx  = principal.WithPrincipal(context.Background(), &model.Principal{Subject: "admin", Role: model.RoleAdmin})
+	leadCtx   = principal.WithPrincipal(context.Background(), &model.Principal{Subject: "lead1", UserID: "lead1", Role: model.RoleLead})
+	memberCtx = principal.WithPrincipal(context.Background(), &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleMember})
+)
+
+func TestTeamPolicy_CreateTeam(t *testing.T) {
+	team := &model.Team{TeamName: "backend"}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		setupMocks    func(*mocks.TeamService)
+		expectedError string
+	}{
+		{
+			name: "Success - admin",
+			ctx:  adminCtx,
+			setupMocks: func(teamService *mocks.TeamService) {
+				teamService.On("CreateTeam", adminCtx, team).Return(team, nil).Once()
+			},
+		},
+		{
+			name:          "Error - lead",
+			ctx:           leadCtx,
+			setupMocks:    func(teamService *mocks.TeamService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name:          "Error - no principal",
+			ctx:           context.Background(),
+			setupMocks:    func(teamService *mocks.TeamService) {},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			teamService := new(mocks.TeamService)
+			userService := new(mocks.UserService)
+			test.setupMocks(teamService)
+
+			p := policy.NewTeamPolicy(teamService, userService)
+			created, err := p.CreateTeam(test.ctx, team)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, created)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, team, created)
+			}
+			teamService.AssertExpectations(t)
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamPolicy_UpdateSettings(t *testing.T) {
+	maxReviewers := 3
+	settings := model.TeamSettingsUpdate{MaxReviewers: &maxReviewers}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		teamName      string
+		setupMocks    func(*mocks.TeamService, *mocks.UserService)
+		expectedError string
+	}{
+		{
+			name:     "Success - admin without lookup",
+			ctx:      adminCtx,
+			teamName: "backend",
+			setupMocks: func(teamService *mocks.TeamService, userService *mocks.UserService) {
+				teamService.On("UpdateSettings", adminCtx, "backend", settings).Return(&model.Team{TeamName: "backend"}, nil).Once()
+			},
+		},
+		{
+			name:     "Success - lead of the team",
+			ctx:      leadCtx,
+			teamName: "backend",
+			setupMocks: func(teamService *mocks.TeamService, userService *mocks.UserService) {
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+				teamService.On("UpdateSettings", leadCtx, "backend", settings).Return(&model.Team{TeamName: "backend"}, nil).Once()
+			},
+		},
+		{
+			name:     "Error - lead of another team",
+			ctx:      leadCtx,
+			teamName: "frontend",
+			setupMocks: func(teamService *mocks.TeamService, userService *mocks.UserService) {
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name:          "Error - member",
+			ctx:           memberCtx,
+			teamName:      "backend",
+			setupMocks:    func(teamService *mocks.TeamService, userService *mocks.UserService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			teamService := new(mocks.TeamService)
+			userService := new(mocks.UserService)
+			test.setupMocks(teamService, userService)
+
+			p := policy.NewTeamPolicy(teamService, userService)
+			_, err := p.UpdateSettings(test.ctx, test.teamName, settings)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			teamService.AssertExpectations(t)
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserPolicy_SetActiveStatus(t *testing.T) {
+	change := &model.UserStatusChange{User: &model.User{UserID: "user2"}}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		setupMocks    func(*mocks.UserService)
+		expectedError string
+	}{
+		{
+			name: "Success - admin",
+			ctx:  adminCtx,
+			setupMocks: func(userService *mocks.UserService) {
+				userService.On("SetActiveStatus", adminCtx, "user2", false).Return(change, nil).Once()
+			},
+		},
+		{
+			name: "Success - lead of the user's team",
+			ctx:  leadCtx,
+			setupMocks: func(userService *mocks.UserService) {
+				userService.On("GetUserByID", leadCtx, "user2").Return(&model.User{UserID: "user2", TeamName: "backend"}, nil).Once()
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+				userService.On("SetActiveStatus", leadCtx, "user2", false).Return(change, nil).Once()
+			},
+		},
+		{
+			name: "Error - lead of another team",
+			ctx:  leadCtx,
+			setupMocks: func(userService *mocks.UserService) {
+				userService.On("GetUserByID", leadCtx, "user2").Return(&model.User{UserID: "user2", TeamName: "frontend"}, nil).Once()
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Error - member",
+			ctx:  memberCtx,
+			setupMocks: func(userService *mocks.UserService) {
+				userService.On("GetUserByID", memberCtx, "user2").Return(&model.User{UserID: "user2", TeamName: "backend"}, nil).Once()
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Error - user not found",
+			ctx:  leadCtx,
+			setupMocks: func(userService *mocks.UserService) {
+				userService.On("GetUserByID", leadCtx, "user2").Return(nil, model.ErrUserNotFound).Once()
+			},
+			expectedError: "get user: " + model.ErrUserNotFound.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userService := new(mocks.UserService)
+			test.setupMocks(userService)
+
+			p := policy.NewUserPolicy(userService)
+			result, err := p.SetActiveStatus(test.ctx, "user2", false)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, change, result)
+			}
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestPolicy_Merge(t *testing.T) {
+	pr := &model.PullRequest{PullRequestID: "pr1", AuthorID: "user1"}
+	merged := &model.PullRequest{PullRequestID: "pr1", AuthorID: "user1", Status: model.PullRequestMerged}
+	otherCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "user2", UserID: "user2", Role: model.RoleMember})
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		opts          model.MergeOptions
+		setupMocks    func(*mocks.PullRequestService, *mocks.UserService)
+		expectedError string
+	}{
+		{
+			name: "Success - admin override",
+			ctx:  adminCtx,
+			opts: model.MergeOptions{Override: true},
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("Merge", adminCtx, "pr1", model.MergeOptions{Override: true}).Return(merged, nil).Once()
+			},
+		},
+		{
+			name: "Success - author",
+			ctx:  memberCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("GetPullRequest", memberCtx, "pr1").Return(pr, nil).Once()
+				prService.On("Merge", memberCtx, "pr1", model.MergeOptions{MergedBy: "user1"}).Return(merged, nil).Once()
+			},
+		},
+		{
+			name: "Success - lead of the author's team",
+			ctx:  leadCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("GetPullRequest", leadCtx, "pr1").Return(pr, nil).Once()
+				userService.On("GetUserByID", leadCtx, "user1").Return(&model.User{UserID: "user1", TeamName: "backend"}, nil).Once()
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+				prService.On("Merge", leadCtx, "pr1", model.MergeOptions{MergedBy: "lead1"}).Return(merged, nil).Once()
+			},
+		},
+		{
+			name: "Error - member merging someone else's PR",
+			ctx:  otherCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("GetPullRequest", otherCtx, "pr1").Return(pr, nil).Once()
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name:          "Error - member merges as someone else",
+			ctx:           memberCtx,
+			opts:          model.MergeOptions{MergedBy: "user2"},
+			setupMocks:    func(prService *mocks.PullRequestService, userService *mocks.UserService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Success - admin keeps merged_by from request",
+			ctx:  adminCtx,
+			opts: model.MergeOptions{MergedBy: "user2"},
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("Merge", adminCtx, "pr1", model.MergeOptions{MergedBy: "user2"}).Return(merged, nil).Once()
+			},
+		},
+		{
+			name:          "Error - override by member",
+			ctx:           memberCtx,
+			opts:          model.MergeOptions{Override: true},
+			setupMocks:    func(prService *mocks.PullRequestService, userService *mocks.UserService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Error - PR not found",
+			ctx:  memberCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("GetPullRequest", memberCtx, "pr1").Return(nil, model.ErrPullRequestNotFound).Once()
+			},
+			expectedError: "get PR: " + model.ErrPullRequestNotFound.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prService := new(mocks.PullRequestService)
+			userService := new(mocks.UserService)
+			test.setupMocks(prService, userService)
+
+			p := policy.NewPullRequestPolicy(prService, userService)
+			result, err := p.Merge(test.ctx, "pr1", test.opts)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, merged, result)
+			}
+			prService.AssertExpectations(t)
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestPolicy_StatusTransitions(t *testing.T) {
+	pr := &model.PullRequest{PullRequestID: "pr1", AuthorID: "user1"}
+	updated := &model.PullRequest{PullRequestID: "pr1", AuthorID: "user1", Status: model.PullRequestOpen}
+	otherCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "user2", UserID: "user2", Role: model.RoleMember})
+
+	actions := []struct {
+		name string
+		call func(*policy.PullRequestPolicy, context.Context) (*model.PullRequest, error)
+	}{
+		{
+			name: "MarkReady",
+			call: func(p *policy.PullRequestPolicy, ctx context.Context) (*model.PullRequest, error) {
+				return p.MarkReady(ctx, "pr1")
+			},
+		},
+		{
+			name: "Close",
+			call: func(p *policy.PullRequestPolicy, ctx context.Context) (*model.PullRequest, error) {
+				return p.Close(ctx, "pr1")
+			},
+		},
+		{
+			name: "Reopen",
+			call: func(p *policy.PullRequestPolicy, ctx context.Context) (*model.PullRequest, error) {
+				return p.Reopen(ctx, "pr1")
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		setupMocks    func(*mocks.PullRequestService, *mocks.UserService, string)
+		expectedError string
+	}{
+		{
+			name: "Success - admin",
+			ctx:  adminCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService, action string) {
+				prService.On(action, adminCtx, "pr1").Return(updated, nil).Once()
+			},
+		},
+		{
+			name: "Success - author",
+			ctx:  memberCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService, action string) {
+				prService.On("GetPullRequest", memberCtx, "pr1").Return(pr, nil).Once()
+				prService.On(action, memberCtx, "pr1").Return(updated, nil).Once()
+			},
+		},
+		{
+			name: "Success - lead of the author's team",
+			ctx:  leadCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService, action string) {
+				prService.On("GetPullRequest", leadCtx, "pr1").Return(pr, nil).Once()
+				userService.On("GetUserByID", leadCtx, "user1").Return(&model.User{UserID: "user1", TeamName: "backend"}, nil).Once()
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+				prService.On(action, leadCtx, "pr1").Return(updated, nil).Once()
+			},
+		},
+		{
+			name: "Error - lead of another team",
+			ctx:  leadCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService, action string) {
+				prService.On("GetPullRequest", leadCtx, "pr1").Return(pr, nil).Once()
+				userService.On("GetUserByID", leadCtx, "user1").Return(&model.User{UserID: "user1", TeamName: "frontend"}, nil).Once()
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Error - member acting on someone else's PR",
+			ctx:  otherCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService, action string) {
+				prService.On("GetPullRequest", otherCtx, "pr1").Return(pr, nil).Once()
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Error - PR not found",
+			ctx:  memberCtx,
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService, action string) {
+				prService.On("GetPullRequest", memberCtx, "pr1").Return(nil, model.ErrPullRequestNotFound).Once()
+			},
+			expectedError: "get PR: " + model.ErrPullRequestNotFound.Error(),
+		},
+	}
+
+	for _, action := range actions {
+		for _, test := range tests {
+			t.Run(action.name+"/"+test.name, func(t *testing.T) {
+				prService := new(mocks.PullRequestService)
+				userService := new(mocks.UserService)
+				test.setupMocks(prService, userService, action.name)
+
+				p := policy.NewPullRequestPolicy(prService, userService)
+				result, err := action.call(p, test.ctx)
+
+				if test.expectedError != "" {
+					assert.EqualError(t, err, test.expectedError)
+					assert.Nil(t, result)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, updated, result)
+				}
+				prService.AssertExpectations(t)
+				userService.AssertExpectations(t)
+			})
+		}
+	}
+}
+
+func TestPullRequestPolicy_Reassign(t *testing.T) {
+	pr := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1"}
+	response := &model.ReassignResponse{}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		oldReviewerID string
+		setupMocks    func(*mocks.PullRequestService, *mocks.UserService)
+		expectedError string
+	}{
+		{
+			name:          "Success - member reassigns own review",
+			ctx:           memberCtx,
+			oldReviewerID: "user1",
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("Reassign", memberCtx, "pr1", "user1").Return(response, nil).Once()
+			},
+		},
+		{
+			name:          "Success - admin",
+			ctx:           adminCtx,
+			oldReviewerID: "user3",
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("Reassign", adminCtx, "pr1", "user3").Return(response, nil).Once()
+			},
+		},
+		{
+			name:          "Success - lead of the author's team",
+			ctx:           leadCtx,
+			oldReviewerID: "user3",
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("GetPullRequest", leadCtx, "pr1").Return(pr, nil).Once()
+				userService.On("GetUserByID", leadCtx, "author1").Return(&model.User{UserID: "author1", TeamName: "backend"}, nil).Once()
+				userService.On("GetUserByID", leadCtx, "lead1").Return(&model.User{UserID: "lead1", TeamName: "backend"}, nil).Once()
+				prService.On("Reassign", leadCtx, "pr1", "user3").Return(response, nil).Once()
+			},
+		},
+		{
+			name:          "Error - member reassigns someone else's review",
+			ctx:           memberCtx,
+			oldReviewerID: "user3",
+			setupMocks: func(prService *mocks.PullRequestService, userService *mocks.UserService) {
+				prService.On("GetPullRequest", memberCtx, "pr1").Return(pr, nil).Once()
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prService := new(mocks.PullRequestService)
+			userService := new(mocks.UserService)
+			test.setupMocks(prService, userService)
+
+			p := policy.NewPullRequestPolicy(prService, userService)
+			result, err := p.Reassign(test.ctx, "pr1", test.oldReviewerID)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, response, result)
+			}
+			prService.AssertExpectations(t)
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestPolicy_Create(t *testing.T) {
+	created := &model.PullRequest{PullRequestID: "pr1", AuthorID: "user1"}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		authorID      string
+		setupMocks    func(*mocks.PullRequestService)
+		expectedError string
+	}{
+		{
+			name:     "Success - author taken from principal",
+			ctx:      memberCtx,
+			authorID: "",
+			setupMocks: func(prService *mocks.PullRequestService) {
+				prService.On("Create", memberCtx, "pr1", "Feature", "user1", false).Return(created, nil).Once()
+			},
+		},
+		{
+			name:     "Success - admin creates for another author",
+			ctx:      adminCtx,
+			authorID: "user2",
+			setupMocks: func(prService *mocks.PullRequestService) {
+				prService.On("Create", adminCtx, "pr1", "Feature", "user2", false).Return(created, nil).Once()
+			},
+		},
+		{
+			name:          "Error - member creates for another author",
+			ctx:           memberCtx,
+			authorID:      "user2",
+			setupMocks:    func(prService *mocks.PullRequestService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prService := new(mocks.PullRequestService)
+			userService := new(mocks.UserService)
+			test.setupMocks(prService)
+
+			p := policy.NewPullRequestPolicy(prService, userService)
+			result, err := p.Create(test.ctx, "pr1", "Feature", test.authorID, false)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, created, result)
+			}
+			prService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestPolicy_SubmitReview(t *testing.T) {
+	reviewed := &model.PullRequest{PullRequestID: "pr1"}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		reviewerID    string
+		setupMocks    func(*mocks.PullRequestService)
+		expectedError string
+	}{
+		{
+			name:       "Success - reviewer taken from principal",
+			ctx:        memberCtx,
+			reviewerID: "",
+			setupMocks: func(prService *mocks.PullRequestService) {
+				prService.On("SubmitReview", memberCtx, "pr1", "user1", model.ReviewStateApproved).Return(reviewed, nil).Once()
+			},
+		},
+		{
+			name:       "Success - matching reviewer",
+			ctx:        memberCtx,
+			reviewerID: "user1",
+			setupMocks: func(prService *mocks.PullRequestService) {
+				prService.On("SubmitReview", memberCtx, "pr1", "user1", model.ReviewStateApproved).Return(reviewed, nil).Once()
+			},
+		},
+		{
+			name:          "Error - member approves as someone else",
+			ctx:           memberCtx,
+			reviewerID:    "user2",
+			setupMocks:    func(prService *mocks.PullRequestService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name:          "Error - no principal",
+			ctx:           context.Background(),
+			reviewerID:    "user1",
+			setupMocks:    func(prService *mocks.PullRequestService) {},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prService := new(mocks.PullRequestService)
+			userService := new(mocks.UserService)
+			test.setupMocks(prService)
+
+			p := policy.NewPullRequestPolicy(prService, userService)
+			result, err := p.SubmitReview(test.ctx, "pr1", test.reviewerID, model.ReviewStateApproved)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, reviewed, result)
+			}
+			prService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookPolicy_CreateSubscription(t *testing.T) {
+	sub := &model.WebhookSubscription{URL: "http://example.com/hook"}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		setupMocks    func(*mocks.WebhookService)
+		expectedError string
+	}{
+		{
+			name: "Success - admin",
+			ctx:  adminCtx,
+			setupMocks: func(webhookService *mocks.WebhookService) {
+				webhookService.On("CreateSubscription", adminCtx, sub).Return(sub, nil).Once()
+			},
+		},
+		{
+			name:          "Error - lead",
+			ctx:           leadCtx,
+			setupMocks:    func(webhookService *mocks.WebhookService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name:          "Error - no principal",
+			ctx:           context.Background(),
+			setupMocks:    func(webhookService *mocks.WebhookService) {},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhookService := new(mocks.WebhookService)
+			test.setupMocks(webhookService)
+
+			p := policy.NewWebhookPolicy(webhookService)
+			created, err := p.CreateSubscription(test.ctx, sub)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, created)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, sub, created)
+			}
+			webhookService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIntegrationPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		call          func(context.Context, *policy.IntegrationPolicy) error
+		setupMocks    func(*mocks.IntegrationService)
+		expectedError string
+	}{
+		{
+			name: "Success - admin deletes identity",
+			ctx:  adminCtx,
+			call: func(ctx context.Context, p *policy.IntegrationPolicy) error {
+				return p.DeleteIdentity(ctx, model.ProviderGitHub, "octocat")
+			},
+			setupMocks: func(integrationService *mocks.IntegrationService) {
+				integrationService.On("DeleteIdentity", adminCtx, model.ProviderGitHub, "octocat").Return(nil).Once()
+			},
+		},
+		{
+			name: "Error - member deletes identity",
+			ctx:  memberCtx,
+			call: func(ctx context.Context, p *policy.IntegrationPolicy) error {
+				return p.DeleteIdentity(ctx, model.ProviderGitHub, "octocat")
+			},
+			setupMocks:    func(integrationService *mocks.IntegrationService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Error - lead lists rejections",
+			ctx:  leadCtx,
+			call: func(ctx context.Context, p *policy.IntegrationPolicy) error {
+				_, err := p.ListRejections(ctx, model.ProviderGitLab)
+				return err
+			},
+			setupMocks:    func(integrationService *mocks.IntegrationService) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Success - webhook without principal",
+			ctx:  context.Background(),
+			call: func(ctx context.Context, p *policy.IntegrationPolicy) error {
+				_, err := p.HandleGitLabWebhook(ctx, "secret", []byte("{}"))
+				return err
+			},
+			setupMocks: func(integrationService *mocks.IntegrationService) {
+				integrationService.On("HandleGitLabWebhook", context.Background(), "secret", []byte("{}")).
+					Return(&model.IntegrationResult{}, nil).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			integrationService := new(mocks.IntegrationService)
+			test.setupMocks(integrationService)
+
+			err := test.call(test.ctx, policy.NewIntegrationPolicy(integrationService))
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			integrationService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type PullRequestPolicy struct {
+	pullRequestService PullRequestService
+	authorizer         authorizer
+}
+
+func NewPullRequestPolicy(pullRequestService PullRequestService, users UserGetter) *PullRequestPolicy {
+	return &PullRequestPolicy{
+		pullRequestService: pullRequestService,
+		authorizer:         authorizer{users: users},
+	}
+}
+
+func (p *PullRequestPolicy) Create(ctx context.Context, prID, prName, authorID string, isDraft bool) (*model.PullRequest, error) {
+	authorID, err := bindCaller(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	return p.pullRequestService.Create(ctx, prID, prName, authorID, isDraft)
+}
+
+func (p *PullRequestPolicy) MarkReady(ctx context.Context, prID string) (*model.PullRequest, error) {
+	if err := p.requirePullRequestManager(ctx, prID); err != nil {
+		return nil, err
+	}
+	return p.pullRequestService.MarkReady(ctx, prID)
+}
+
+func (p *PullRequestPolicy) Merge(ctx context.Context, prID string, opts model.MergeOptions) (*model.PullRequest, error) {
+	caller, err := currentPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.Role != model.RoleAdmin {
+		if opts.Override {
+			return nil, model.ErrForbidden
+		}
+		if opts.MergedBy, err = bindCaller(ctx, opts.MergedBy); err != nil {
+			return nil, err
+		}
+		if err := p.requirePullRequestManager(ctx, prID); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.pullRequestService.Merge(ctx, prID, opts)
+}
+
+func (p *PullRequestPolicy) Close(ctx context.Context, prID string) (*model.PullRequest, error) {
+	if err := p.requirePullRequestManager(ctx, prID); err != nil {
+		return nil, err
+	}
+	return p.pullRequestService.Close(ctx, prID)
+}
+
+func (p *PullRequestPolicy) Reopen(ctx context.Context, prID string) (*model.PullRequest, error) {
+	if err := p.requirePullRequestManager(ctx, prID); err != nil {
+		return nil, err
+	}
+	return p.pullRequestService.Reopen(ctx, prID)
+}
+
+func (p *PullRequestPolicy) SubmitReview(ctx context.Context, prID, reviewerID string, state model.ReviewState) (*model.PullRequest, error) {
+	reviewerID, err := bindCaller(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	return p.pullRequestService.SubmitReview(ctx, prID, reviewerID, state)
+}
+
+func (p *PullRequestPolicy) GetOverdueReviews(ctx context.Context) ([]model.OverdueReview, error) {
+	return p.pullRequestService.GetOverdueReviews(ctx)
+}
+
+func (p *PullRequestPolicy) GetHistory(ctx context.Context, prID string) (*model.PullRequestHistory, error) {
+	return p.pullRequestService.GetHistory(ctx, prID)
+}
+
+func (p *PullRequestPolicy) Reassign(ctx context.Context, prID, oldReviewerID string) (*model.ReassignResponse, error) {
+	caller, err := currentPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.Role != model.RoleAdmin && oldReviewerID != caller.UserID {
+		pr, err := p.pullRequestService.GetPullRequest(ctx, prID)
+		if err != nil {
+			return nil, fmt.Errorf("get PR: %w", err)
+		}
+		if err := p.requireAuthorTeamLead(ctx, caller, pr.AuthorID); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.pullRequestService.Reassign(ctx, prID, oldReviewerID)
+}
+
+func (p *PullRequestPolicy) requirePullRequestManager(ctx context.Context, prID string) error {
+	caller, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.Role == model.RoleAdmin {
+		return nil
+	}
+
+	pr, err := p.pullRequestService.GetPullRequest(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("get PR: %w", err)
+	}
+	if pr.AuthorID == caller.UserID {
+		return nil
+	}
+	return p.requireAuthorTeamLead(ctx, caller, pr.AuthorID)
+}
+
+func (p *PullRequestPolicy) requireAuthorTeamLead(ctx context.Context, caller *model.Principal, authorID string) error {
+	if caller.Role != model.RoleLead {
+		return model.ErrForbidden
+	}
+
+	teamName, err := p.authorizer.userTeam(ctx, authorID)
+	if err != nil {
+		return err
+	}
+	return p.authorizer.requireTeamManager(ctx, teamName)
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type TeamService interface {
+	CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error)
+	GetTeamByName(ctx context.Context, teamName string) (*model.Team, error)
+	UpdateSettings(ctx context.Context, teamName string, update model.TeamSettingsUpdate) (*model.Team, error)
+	DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*model.TeamDeactivation, error)
+}
+
+type UserService interface {
+	SetActiveStatus(ctx context.Context, userId string, isActive bool) (*model.UserStatusChange, error)
+	GetUserByID(ctx context.Context, userId string) (*model.User, error)
+}
+
+type PullRequestService interface {
+	Create(ctx context.Context, prID, prName, authorID string, isDraft bool) (*model.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (*model.PullRequest, error)
+	Merge(ctx context.Context, prID string, opts model.MergeOptions) (*model.PullRequest, error)
+	Close(ctx context.Context, prID string) (*model.PullRequest, error)
+	Reopen(ctx context.Context, prID string) (*model.PullRequest, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, state model.ReviewState) (*model.PullRequest, error)
+	GetOverdueReviews(ctx context.Context) ([]model.OverdueReview, error)
+	GetHistory(ctx context.Context, prID string) (*model.PullRequestHistory, error)
+	GetPullRequest(ctx context.Context, prID string) (*model.PullRequest, error)
+	Reassign(ctx context.Context, prID, oldReviewerID string) (*model.ReassignResponse, error)
+}
+
+type UserGetter interface {
+	GetUserByID(ctx context.Context, userId string) (*model.User, error)
+}
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+}
+
+type AuditService interface {
+	List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, error)
+}
+
+type IntegrationService interface {
+	SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error)
+	ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error)
+	DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error
+	ListRejections(ctx context.Context, provider model.ExternalProvider) ([]model.IntegrationRejection, error)
+	HandleGitHubWebhook(ctx context.Context, eventName, signature string, body []byte) (*model.IntegrationResult, error)
+	HandleGitLabWebhook(ctx context.Context, token string, body []byte) (*model.IntegrationResult, error)
+}
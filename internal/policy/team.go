@@ -0,0 +1,44 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type TeamPolicy struct {
+	teamService TeamService
+	authorizer  authorizer
+}
+
+func NewTeamPolicy(teamService TeamService, users UserGetter) *TeamPolicy {
+	return &TeamPolicy{
+		teamService: teamService,
+		authorizer:  authorizer{users: users},
+	}
+}
+
+func (p *TeamPolicy) CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.teamService.CreateTeam(ctx, team)
+}
+
+func (p *TeamPolicy) GetTeamByName(ctx context.Context, teamName string) (*model.Team, error) {
+	return p.teamService.GetTeamByName(ctx, teamName)
+}
+
+func (p *TeamPolicy) UpdateSettings(ctx context.Context, teamName string, update model.TeamSettingsUpdate) (*model.Team, error) {
+	if err := p.authorizer.requireTeamManager(ctx, teamName); err != nil {
+		return nil, err
+	}
+	return p.teamService.UpdateSettings(ctx, teamName, update)
+}
+
+func (p *TeamPolicy) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*model.TeamDeactivation, error) {
+	if err := p.authorizer.requireTeamManager(ctx, teamName); err != nil {
+		return nil, err
+	}
+	return p.teamService.DeactivateUsers(ctx, teamName, userIDs)
+}
@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type UserPolicy struct {
+	userService UserService
+	authorizer  authorizer
+}
+
+func NewUserPolicy(userService UserService) *UserPolicy {
+	return &UserPolicy{
+		userService: userService,
+		authorizer:  authorizer{users: userService},
+	}
+}
+
+func (p *UserPolicy) SetActiveStatus(ctx context.Context, userId string, isActive bool) (*model.UserStatusChange, error) {
+	caller, err := currentPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.Role != model.RoleAdmin {
+		teamName, err := p.authorizer.userTeam(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.authorizer.requireTeamManager(ctx, teamName); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.userService.SetActiveStatus(ctx, userId, isActive)
+}
+
+func (p *UserPolicy) GetUserByID(ctx context.Context, userId string) (*model.User, error) {
+	return p.userService.GetUserByID(ctx, userId)
+}
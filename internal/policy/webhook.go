@@ -0,0 +1,50 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type WebhookPolicy struct {
+	webhookService WebhookService
+}
+
+func NewWebhookPolicy(webhookService WebhookService) *WebhookPolicy {
+	return &WebhookPolicy{webhookService: webhookService}
+}
+
+func (p *WebhookPolicy) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.webhookService.CreateSubscription(ctx, sub)
+}
+
+func (p *WebhookPolicy) GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.webhookService.GetSubscription(ctx, id)
+}
+
+func (p *WebhookPolicy) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.webhookService.ListSubscriptions(ctx)
+}
+
+func (p *WebhookPolicy) UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return p.webhookService.UpdateSubscription(ctx, sub)
+}
+
+func (p *WebhookPolicy) DeleteSubscription(ctx context.Context, id int64) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	return p.webhookService.DeleteSubscription(ctx, id)
+}
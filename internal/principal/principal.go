@@ -0,0 +1,18 @@
+package principal
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type contextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *model.Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (*model.Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(*model.Principal)
+	return principal, ok && principal != nil
+}
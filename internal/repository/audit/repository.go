@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func NewAuditRepository(pgxpool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{pgxpool: pgxpool}
+}
+
+func (r *AuditRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
+func (r *AuditRepository) Add(ctx context.Context, event model.AuditEvent, before, after []byte) error {
+	sql, args, err := squirrel.Insert("audit_events").
+		Columns("tenant_id", "actor", "action", "target_type", "target_id", "pull_request_id", "user_id", "team_name", "before", "after").
+		Values(
+			tenant.FromContext(ctx),
+			event.Actor,
+			string(event.Action),
+			string(event.TargetType),
+			event.TargetID,
+			nullable(event.PullRequestID),
+			nullable(event.UserID),
+			nullable(event.TeamName),
+			before,
+			after,
+		).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build add audit event query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("add audit event: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, error) {
+	builder := squirrel.Select(
+		"id", "actor", "action", "target_type", "target_id",
+		"COALESCE(pull_request_id, '')", "COALESCE(user_id, '')", "COALESCE(team_name, '')",
+		"before", "after", "created_at",
+	).
+		From("audit_events").
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx)}).
+		OrderBy("id DESC").
+		Limit(uint64(filter.Limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if filter.PullRequestID != "" {
+		builder = builder.Where(squirrel.Eq{"pull_request_id": filter.PullRequestID})
+	}
+	if filter.UserID != "" {
+		builder = builder.Where(squirrel.Or{
+			squirrel.Eq{"user_id": filter.UserID},
+			squirrel.Eq{"actor": filter.UserID},
+		})
+	}
+	if filter.TeamName != "" {
+		builder = builder.Where(squirrel.Eq{"team_name": filter.TeamName})
+	}
+	if filter.Action != "" {
+		builder = builder.Where(squirrel.Eq{"action": string(filter.Action)})
+	}
+	if filter.From != nil {
+		builder = builder.Where(squirrel.GtOrEq{"created_at": *filter.From})
+	}
+	if filter.To != nil {
+		builder = builder.Where(squirrel.Lt{"created_at": *filter.To})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list audit events query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []model.AuditEvent{}
+	for rows.Next() {
+		var event model.AuditEvent
+		var before, after []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.Actor,
+			&event.Action,
+			&event.TargetType,
+			&event.TargetID,
+			&event.PullRequestID,
+			&event.UserID,
+			&event.TeamName,
+			&before,
+			&after,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan audit event: %w", err)
+		}
+		if before != nil {
+			event.Before = json.RawMessage(before)
+		}
+		if after != nil {
+			event.After = json.RawMessage(after)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("audit events rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+func nullable(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
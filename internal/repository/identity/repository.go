@@ -0,0 +1,138 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdentityRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func NewIdentityRepository(pgxpool *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{pgxpool: pgxpool}
+}
+
+func (r *IdentityRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
+func (r *IdentityRepository) SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error) {
+	sql, args, err := squirrel.Insert("external_identities").
+		Columns("tenant_id", "provider", "external_login", "user_id").
+		Values(tenant.FromContext(ctx), string(identity.Provider), identity.ExternalLogin, identity.UserID).
+		Suffix("ON CONFLICT (tenant_id, provider, external_login) DO UPDATE SET user_id = EXCLUDED.user_id RETURNING created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build save identity query: %w", err)
+	}
+
+	saved := *identity
+	if err := r.conn(ctx).QueryRow(ctx, sql, args...).Scan(&saved.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("save identity: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func (r *IdentityRepository) GetIdentity(ctx context.Context, provider model.ExternalProvider, login string) (*model.ExternalIdentity, error) {
+	sql, args, err := identitiesQuery().
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "provider": string(provider), "external_login": login}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build get identity query: %w", err)
+	}
+
+	identity, err := scanIdentity(r.conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("get identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func (r *IdentityRepository) ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error) {
+	builder := identitiesQuery().
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx)}).
+		OrderBy("provider", "external_login")
+	if provider != "" {
+		builder = builder.Where(squirrel.Eq{"provider": string(provider)})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list identities query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []model.ExternalIdentity{}
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan identity: %w", err)
+		}
+		identities = append(identities, *identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("identities rows error: %w", err)
+	}
+
+	return identities, nil
+}
+
+func (r *IdentityRepository) DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error {
+	sql, args, err := squirrel.Delete("external_identities").
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "provider": string(provider), "external_login": login}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build delete identity query: %w", err)
+	}
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("delete identity: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return model.ErrIdentityNotFound
+	}
+	return nil
+}
+
+func identitiesQuery() squirrel.SelectBuilder {
+	return squirrel.Select("provider", "external_login", "user_id", "created_at").
+		From("external_identities").
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func scanIdentity(row pgx.Row) (*model.ExternalIdentity, error) {
+	var identity model.ExternalIdentity
+	if err := row.Scan(&identity.Provider, &identity.ExternalLogin, &identity.UserID, &identity.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pgxpool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pgxpool: pgxpool}
+}
+
+func (r *OutboxRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, eventType model.EventType, payload []byte) error {
+	sql, args, err := squirrel.Insert("outbox_events").
+		Columns("tenant_id", "event_type", "payload").
+		Values(tenant.FromContext(ctx), string(eventType), payload).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build add outbox event query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("add outbox event: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) LockPending(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	sql, args, err := squirrel.Select("id", "tenant_id", "event_type", "payload", "attempts", "delivered_sinks", "created_at").
+		From("outbox_events").
+		Where(squirrel.Eq{"delivered_at": nil, "dead_at": nil}).
+		Where("next_attempt_at <= now()").
+		OrderBy("next_attempt_at", "id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build lock pending outbox events query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pending outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []model.OutboxEvent{}
+	for rows.Next() {
+		var event model.OutboxEvent
+		if err := rows.Scan(&event.ID, &event.TenantID, &event.Type, &event.Payload, &event.Attempts, &event.DeliveredSinks, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("outbox events rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, ids []int64) error {
+	sql, args, err := squirrel.Update("outbox_events").
+		Set("delivered_at", time.Now()).
+		Set("last_error", nil).
+		Where(squirrel.Eq{"id": ids}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build mark outbox events delivered query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("mark outbox events delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, deliveredSinks []string, lastError string, nextAttemptAt *time.Time) error {
+	builder := squirrel.Update("outbox_events").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("delivered_sinks", deliveredSinks).
+		Set("last_error", lastError).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar)
+	if nextAttemptAt != nil {
+		builder = builder.Set("next_attempt_at", *nextAttemptAt)
+	} else {
+		builder = builder.Set("dead_at", time.Now())
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("build mark outbox event failed query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("mark outbox event failed: %w", err)
+	}
+	return nil
+}
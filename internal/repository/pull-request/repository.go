@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,6 +24,10 @@ func NewPullRequestRepository(pgxpool *pgxpool.Pool) *PullRequestRepository {
 	return &PullRequestRepository{pgxpool: pgxpool}
 }
 
+func (r *PullRequestRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
 func (r *PullRequestRepository) Get(ctx context.Context, id string) (*model.PullRequest, error) {
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 	sql, args, err := psql.Select(
@@ -30,9 +37,12 @@ func (r *PullRequestRepository) Get(ctx context.Context, id string) (*model.Pull
 		"status",
 		"created_at",
 		"merged_at",
+		"closed_at",
+		"merged_by",
+		"merge_override",
 	).
 		From("pull_requests").
-		Where(squirrel.Eq{"pull_request_id": id}).
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "pull_request_id": id}).
 		ToSql()
 
 	if err != nil {
@@ -40,13 +50,16 @@ func (r *PullRequestRepository) Get(ctx context.Context, id string) (*model.Pull
 	}
 
 	var pr model.PullRequest
-	err = r.pgxpool.QueryRow(ctx, sql, args...).Scan(
+	err = r.conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
 		&pr.Status,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.ClosedAt,
+		&pr.MergedBy,
+		&pr.MergeOverride,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -64,16 +77,17 @@ func (r *PullRequestRepository) Get(ctx context.Context, id string) (*model.Pull
 	return &updatedPRs[0], nil
 }
 
-func (r *PullRequestRepository) Create(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
-	tx, err := r.pgxpool.Begin(ctx)
+func (r *PullRequestRepository) Create(ctx context.Context, pr *model.PullRequest, dueAt *time.Time) (*model.PullRequest, error) {
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
+	tenantID := tenant.FromContext(ctx)
 	sql, args, err := squirrel.Insert("pull_requests").
-		Columns("pull_request_id", "pull_request_name", "author_id", "status", "created_at").
-		Values(pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.CreatedAt).
+		Columns("tenant_id", "pull_request_id", "pull_request_name", "author_id", "status", "created_at").
+		Values(tenantID, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.CreatedAt).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -91,10 +105,10 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *model.PullReques
 
 	if len(pr.AssignedReviewers) > 0 {
 		builder := squirrel.Insert("reviewers").
-			Columns("pull_request_id", "user_id").
+			Columns("tenant_id", "pull_request_id", "user_id", "due_at", "reason").
 			PlaceholderFormat(squirrel.Dollar)
 		for _, reviewerID := range pr.AssignedReviewers {
-			builder = builder.Values(pr.PullRequestID, reviewerID)
+			builder = builder.Values(tenantID, pr.PullRequestID, reviewerID, dueAt, model.AssignmentReasonInitial)
 		}
 		sql, args, err := builder.ToSql()
 		if err != nil {
@@ -110,48 +124,213 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *model.PullReques
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return pr, nil
+	return r.Get(ctx, pr.PullRequestID)
 }
 
-func (r *PullRequestRepository) Merge(ctx context.Context, id string) (*model.PullRequest, error) {
+func (r *PullRequestRepository) Merge(ctx context.Context, id string, opts model.MergeOptions) (*model.PullRequest, bool, error) {
+	var mergedBy *string
+	if opts.MergedBy != "" {
+		mergedBy = &opts.MergedBy
+	}
+
 	sql, args, err := squirrel.Update("pull_requests").
 		Set("status", model.PullRequestMerged).
 		Set("merged_at", time.Now()).
-		Where(squirrel.Eq{"pull_request_id": id}).
+		Set("merged_by", mergedBy).
+		Set("merge_override", opts.Override).
+		Where(squirrel.Eq{
+			"tenant_id":       tenant.FromContext(ctx),
+			"pull_request_id": id,
+			"status":          model.PullRequestOpen,
+		}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build merge pull request query: %w", err)
+		return nil, false, fmt.Errorf("failed to build merge pull request query: %w", err)
 	}
 
-	result, err := r.pgxpool.Exec(ctx, sql, args...)
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to merge pull request: %w", err)
+		return nil, false, fmt.Errorf("failed to merge pull request: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return nil, model.ErrPullRequestNotFound
+		pr, err := r.notMerged(ctx, id)
+		return pr, false, err
 	}
 
-	return r.Get(ctx, id)
+	pr, err := r.Get(ctx, id)
+	return pr, true, err
+}
+
+func (r *PullRequestRepository) notMerged(ctx context.Context, id string) (*model.PullRequest, error) {
+	pr, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch pr.Status {
+	case model.PullRequestMerged:
+		return pr, nil
+	case model.PullRequestClosed:
+		return nil, model.ErrPRClosed
+	case model.PullRequestDraft:
+		return nil, model.ErrPRDraft
+	}
+	return nil, model.ErrPullRequestNotFound
 }
 
-func (r *PullRequestRepository) UpdateReviewer(ctx context.Context, pullRequestID, oldReviewerID, newReviewerID string) error {
+func (r *PullRequestRepository) Close(ctx context.Context, id string) (*model.PullRequest, bool, error) {
+	sql, args, err := squirrel.Update("pull_requests").
+		Set("status", model.PullRequestClosed).
+		Set("status_before_close", squirrel.Expr("status")).
+		Set("closed_at", time.Now()).
+		Where(squirrel.Eq{
+			"tenant_id":       tenant.FromContext(ctx),
+			"pull_request_id": id,
+			"status":          []model.PullRequestStatus{model.PullRequestOpen, model.PullRequestDraft},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to build close pull request query: %w", err)
+	}
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to close pull request: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		pr, err := r.unchanged(ctx, id)
+		return pr, false, err
+	}
+
+	pr, err := r.Get(ctx, id)
+	return pr, err == nil, err
+}
+
+func (r *PullRequestRepository) Reopen(ctx context.Context, id string) (*model.PullRequest, bool, error) {
+	sql, args, err := squirrel.Update("pull_requests").
+		Set("status", squirrel.Expr("COALESCE(status_before_close, ?)", model.PullRequestOpen)).
+		Set("status_before_close", nil).
+		Set("closed_at", nil).
+		Where(squirrel.Eq{
+			"tenant_id":       tenant.FromContext(ctx),
+			"pull_request_id": id,
+			"status":          model.PullRequestClosed,
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to build reopen pull request query: %w", err)
+	}
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reopen pull request: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		pr, err := r.unchanged(ctx, id)
+		return pr, false, err
+	}
+
+	pr, err := r.Get(ctx, id)
+	return pr, err == nil, err
+}
+
+func (r *PullRequestRepository) unchanged(ctx context.Context, id string) (*model.PullRequest, error) {
+	pr, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == model.PullRequestMerged {
+		return nil, model.ErrPRMergedNoClose
+	}
+	return pr, nil
+}
+
+func (r *PullRequestRepository) MarkReady(ctx context.Context, id string, reviewerIDs []string, dueAt *time.Time) (*model.PullRequest, bool, error) {
+	tx, err := r.conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tenantID := tenant.FromContext(ctx)
+	sql, args, err := squirrel.Update("pull_requests").
+		Set("status", model.PullRequestOpen).
+		Where(squirrel.Eq{
+			"tenant_id":       tenantID,
+			"pull_request_id": id,
+			"status":          model.PullRequestDraft,
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to build mark ready query: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to mark pull request ready: %w", err)
+	}
+
+	ready := result.RowsAffected() > 0
+	if ready && len(reviewerIDs) > 0 {
+		builder := squirrel.Insert("reviewers").
+			Columns("tenant_id", "pull_request_id", "user_id", "due_at", "reason").
+			PlaceholderFormat(squirrel.Dollar)
+		for _, reviewerID := range reviewerIDs {
+			builder = builder.Values(tenantID, id, reviewerID, dueAt, model.AssignmentReasonInitial)
+		}
+		sql, args, err := builder.ToSql()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to build insert reviewers query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return nil, false, fmt.Errorf("failed to insert reviewers: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pr, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return pr, ready, nil
+}
+
+func (r *PullRequestRepository) UpdateReviewer(ctx context.Context, pullRequestID, oldReviewerID, newReviewerID string, reason model.AssignmentReason, dueAt *time.Time) error {
+	return r.ReplaceReviewers(ctx, []model.ReviewReassignment{{
+		PullRequestID: pullRequestID,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+	}}, reason, dueAt)
+}
+
+func (r *PullRequestRepository) SetReviewState(ctx context.Context, pullRequestID, reviewerID string, state model.ReviewState) error {
 	sql, args, err := squirrel.Update("reviewers").
-		Set("user_id", newReviewerID).
+		Set("state", state).
+		Set("reviewed_at", time.Now()).
 		Where(squirrel.Eq{
+			"tenant_id":       tenant.FromContext(ctx),
 			"pull_request_id": pullRequestID,
-			"user_id":         oldReviewerID,
+			"user_id":         reviewerID,
+			"unassigned_at":   nil,
 		}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build update reviewer query: %w", err)
+		return fmt.Errorf("failed to build set review state query: %w", err)
 	}
 
-	result, err := r.pgxpool.Exec(ctx, sql, args...)
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
-		return fmt.Errorf("failed to update reviewer: %w", err)
+		return fmt.Errorf("failed to set review state: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -171,28 +350,32 @@ func (r *PullRequestRepository) attachReviewersToPullRequests(ctx context.Contex
 		prIDs = append(prIDs, pr.PullRequestID)
 	}
 
-	sqlRev, argsRev, err := squirrel.Select("pull_request_id", "user_id").
+	sqlRev, argsRev, err := squirrel.Select("pull_request_id", "user_id", "state", "assigned_at", "due_at", "reviewed_at").
 		From("reviewers").
-		Where(squirrel.Eq{"pull_request_id": prIDs}).
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "pull_request_id": prIDs, "unassigned_at": nil}).
+		OrderBy("assigned_at", "user_id").
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build reviewers query: %w", err)
 	}
 
-	rowsRev, err := r.pgxpool.Query(ctx, sqlRev, argsRev...)
+	rowsRev, err := r.conn(ctx).Query(ctx, sqlRev, argsRev...)
 	if err != nil {
 		return nil, fmt.Errorf("query reviewers: %w", err)
 	}
 	defer rowsRev.Close()
 
 	reviewersMap := make(map[string][]string)
+	reviewsMap := make(map[string][]model.Review)
 	for rowsRev.Next() {
-		var prID, userID string
-		if err := rowsRev.Scan(&prID, &userID); err != nil {
+		var prID string
+		var review model.Review
+		if err := rowsRev.Scan(&prID, &review.ReviewerID, &review.State, &review.AssignedAt, &review.DueAt, &review.ReviewedAt); err != nil {
 			return nil, fmt.Errorf("scan reviewers: %w", err)
 		}
-		reviewersMap[prID] = append(reviewersMap[prID], userID)
+		reviewersMap[prID] = append(reviewersMap[prID], review.ReviewerID)
+		reviewsMap[prID] = append(reviewsMap[prID], review)
 	}
 
 	if err := rowsRev.Err(); err != nil {
@@ -202,8 +385,10 @@ func (r *PullRequestRepository) attachReviewersToPullRequests(ctx context.Contex
 	for i := range prs {
 		if revs, ok := reviewersMap[prs[i].PullRequestID]; ok {
 			prs[i].AssignedReviewers = revs
+			prs[i].Reviews = reviewsMap[prs[i].PullRequestID]
 		} else {
 			prs[i].AssignedReviewers = []string{}
+			prs[i].Reviews = []model.Review{}
 		}
 	}
 
@@ -220,15 +405,16 @@ func (r *PullRequestRepository) GetUsersPullRequests(ctx context.Context, userId
 		"pr.merged_at",
 	).
 		From("pull_requests pr").
-		Join("reviewers r ON pr.pull_request_id = r.pull_request_id").
-		Where(squirrel.Eq{"r.user_id": userId}).
+		Join("reviewers r ON r.tenant_id = pr.tenant_id AND r.pull_request_id = pr.pull_request_id AND r.unassigned_at IS NULL").
+		Where(squirrel.Eq{"pr.tenant_id": tenant.FromContext(ctx), "r.user_id": userId}).
+		Where(squirrel.NotEq{"pr.status": model.PullRequestDraft}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build pull requests query: %w", err)
 	}
 
-	rows, err := r.pgxpool.Query(ctx, sqlPr, argsPr...)
+	rows, err := r.conn(ctx).Query(ctx, sqlPr, argsPr...)
 	if err != nil {
 		return nil, fmt.Errorf("query pull requests: %w", err)
 	}
@@ -264,3 +450,394 @@ func (r *PullRequestRepository) GetUsersPullRequests(ctx context.Context, userId
 
 	return updatedPRs, nil
 }
+
+func (r *PullRequestRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	sql, args, err := squirrel.Select("r.user_id", "COUNT(*)").
+		From("reviewers r").
+		Join("pull_requests pr ON pr.tenant_id = r.tenant_id AND pr.pull_request_id = r.pull_request_id").
+		Where(squirrel.Eq{
+			"r.tenant_id":     tenant.FromContext(ctx),
+			"r.user_id":       userIDs,
+			"r.unassigned_at": nil,
+			"pr.status":       model.PullRequestOpen,
+		}).
+		GroupBy("r.user_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build open review counts query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query open review counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan open review count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("open review counts rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *PullRequestRepository) LockUnderstaffedPullRequests(ctx context.Context, limit int) ([]model.PullRequest, error) {
+	sql, args, err := squirrel.Select(
+		"pr.pull_request_id",
+		"pr.pull_request_name",
+		"pr.author_id",
+		"pr.status",
+		"pr.created_at",
+		"pr.merged_at",
+	).
+		From("pull_requests pr").
+		Join("users u ON u.tenant_id = pr.tenant_id AND u.user_id = pr.author_id").
+		Join("teams t ON t.tenant_id = u.tenant_id AND t.team_name = u.team_name").
+		Where(squirrel.Eq{"pr.tenant_id": tenant.FromContext(ctx), "pr.status": model.PullRequestOpen}).
+		Where("(pr.fill_retry_at IS NULL OR pr.fill_retry_at <= now())").
+		Where("(SELECT COUNT(*) FROM reviewers r WHERE r.tenant_id = pr.tenant_id AND r.pull_request_id = pr.pull_request_id AND r.unassigned_at IS NULL) < t.max_reviewers").
+		OrderBy("pr.created_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE OF pr SKIP LOCKED").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build understaffed pull requests query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query understaffed pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var pullRequests []model.PullRequest
+	for rows.Next() {
+		var pr model.PullRequest
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.Status,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan pull request: %w", err)
+		}
+		pullRequests = append(pullRequests, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return r.attachReviewersToPullRequests(ctx, pullRequests)
+}
+
+func (r *PullRequestRepository) AddReviewers(ctx context.Context, pullRequestID string, reviewerIDs []string, dueAt *time.Time) ([]string, error) {
+	if len(reviewerIDs) == 0 {
+		return nil, nil
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	builder := squirrel.Insert("reviewers").
+		Columns("tenant_id", "pull_request_id", "user_id", "due_at", "reason").
+		Suffix("ON CONFLICT (tenant_id, pull_request_id, user_id) WHERE unassigned_at IS NULL DO NOTHING RETURNING user_id").
+		PlaceholderFormat(squirrel.Dollar)
+	for _, reviewerID := range reviewerIDs {
+		builder = builder.Values(tenantID, pullRequestID, reviewerID, dueAt, model.AssignmentReasonInitial)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build add reviewers query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("add reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	added := make([]string, 0, len(reviewerIDs))
+	for rows.Next() {
+		var reviewerID string
+		if err := rows.Scan(&reviewerID); err != nil {
+			return nil, fmt.Errorf("scan added reviewer: %w", err)
+		}
+		added = append(added, reviewerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("added reviewers rows error: %w", err)
+	}
+
+	return added, nil
+}
+
+func (r *PullRequestRepository) GetOpenReviewsByUsers(ctx context.Context, userIDs []string) ([]model.PullRequest, error) {
+	sql, args, err := squirrel.Select(
+		"pr.pull_request_id",
+		"pr.pull_request_name",
+		"pr.author_id",
+		"pr.status",
+		"pr.created_at",
+		"pr.merged_at",
+	).
+		From("pull_requests pr").
+		Where(squirrel.Eq{"pr.tenant_id": tenant.FromContext(ctx), "pr.status": model.PullRequestOpen}).
+		Where(squirrel.Expr(
+			"EXISTS (SELECT 1 FROM reviewers r WHERE r.tenant_id = pr.tenant_id AND r.pull_request_id = pr.pull_request_id AND r.unassigned_at IS NULL AND r.user_id = ANY(?))",
+			userIDs,
+		)).
+		OrderBy("pr.created_at").
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build open reviews query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var pullRequests []model.PullRequest
+	for rows.Next() {
+		var pr model.PullRequest
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.Status,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan pull request: %w", err)
+		}
+		pullRequests = append(pullRequests, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return r.attachReviewersToPullRequests(ctx, pullRequests)
+}
+
+func (r *PullRequestRepository) ReplaceReviewers(ctx context.Context, reassignments []model.ReviewReassignment, reason model.AssignmentReason, dueAt *time.Time) error {
+	if len(reassignments) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(reassignments))
+	args := make([]any, 0, len(reassignments)*3+3)
+	args = append(args, dueAt, reason, tenant.FromContext(ctx))
+	for i, reassignment := range reassignments {
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d)", i*3+4, i*3+5, i*3+6))
+		args = append(args, reassignment.PullRequestID, reassignment.OldReviewerID, reassignment.NewReviewerID)
+	}
+
+	sql := "WITH v(pull_request_id, old_reviewer_id, new_reviewer_id) AS (VALUES " + strings.Join(values, ", ") + "), " +
+		"unassigned AS (UPDATE reviewers r SET unassigned_at = now() FROM v " +
+		"WHERE r.tenant_id = $3 AND r.pull_request_id = v.pull_request_id AND r.user_id = v.old_reviewer_id AND r.unassigned_at IS NULL " +
+		"RETURNING r.pull_request_id, r.user_id) " +
+		"INSERT INTO reviewers (tenant_id, pull_request_id, user_id, due_at, reason) " +
+		"SELECT $3, v.pull_request_id, v.new_reviewer_id, $1::timestamp, $2 FROM v " +
+		"JOIN unassigned u ON u.pull_request_id = v.pull_request_id AND u.user_id = v.old_reviewer_id"
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("replace reviewers: %w", err)
+	}
+
+	if result.RowsAffected() != int64(len(reassignments)) {
+		return model.ErrReviewerNotAssigned
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) GetOverdueReviews(ctx context.Context, now time.Time) ([]model.OverdueReview, error) {
+	return r.queryOverdueReviews(ctx, overdueReviewsQuery(tenant.FromContext(ctx), now))
+}
+
+func (r *PullRequestRepository) LockOverdueReviews(ctx context.Context, now time.Time, limit int) ([]model.OverdueReview, error) {
+	return r.queryOverdueReviews(ctx, overdueReviewsQuery(tenant.FromContext(ctx), now).
+		Where(squirrel.Or{
+			squirrel.Eq{"r.escalation_retry_at": nil},
+			squirrel.LtOrEq{"r.escalation_retry_at": now},
+		}).
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE OF r SKIP LOCKED"))
+}
+
+func overdueReviewsQuery(tenantID string, now time.Time) squirrel.SelectBuilder {
+	return squirrel.Select(
+		"pr.pull_request_id",
+		"pr.pull_request_name",
+		"pr.author_id",
+		"r.user_id",
+		"r.assigned_at",
+		"r.due_at",
+	).
+		From("reviewers r").
+		Join("pull_requests pr ON pr.tenant_id = r.tenant_id AND pr.pull_request_id = r.pull_request_id").
+		Where(squirrel.Eq{
+			"r.tenant_id":     tenantID,
+			"pr.status":       model.PullRequestOpen,
+			"r.state":         model.ReviewStatePending,
+			"r.unassigned_at": nil,
+		}).
+		Where(squirrel.Lt{"r.due_at": now}).
+		OrderBy("r.due_at", "pr.pull_request_id").
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func (r *PullRequestRepository) queryOverdueReviews(ctx context.Context, query squirrel.SelectBuilder) ([]model.OverdueReview, error) {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build overdue reviews query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query overdue reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []model.OverdueReview{}
+	for rows.Next() {
+		var review model.OverdueReview
+		if err := rows.Scan(
+			&review.PullRequestID,
+			&review.PullRequestName,
+			&review.AuthorID,
+			&review.ReviewerID,
+			&review.AssignedAt,
+			&review.DueAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan overdue review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("overdue reviews rows error: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *PullRequestRepository) GetReviewerHistory(ctx context.Context, pullRequestID string) ([]model.ReviewerAssignment, error) {
+	sql, args, err := squirrel.Select("user_id", "reason", "state", "assigned_at", "unassigned_at", "due_at", "reviewed_at").
+		From("reviewers").
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "pull_request_id": pullRequestID}).
+		OrderBy("assigned_at", "id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build reviewer history query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewer history: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []model.ReviewerAssignment{}
+	for rows.Next() {
+		var assignment model.ReviewerAssignment
+		if err := rows.Scan(
+			&assignment.ReviewerID,
+			&assignment.Reason,
+			&assignment.State,
+			&assignment.AssignedAt,
+			&assignment.UnassignedAt,
+			&assignment.DueAt,
+			&assignment.ReviewedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan reviewer assignment: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reviewer history rows error: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (r *PullRequestRepository) DeferFill(ctx context.Context, pullRequestID string, retryAt time.Time) error {
+	sql, args, err := squirrel.Update("pull_requests").
+		Set("fill_retry_at", retryAt).
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "pull_request_id": pullRequestID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build defer fill query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("defer fill: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) DeferEscalation(ctx context.Context, pullRequestID, reviewerID string, retryAt time.Time) error {
+	sql, args, err := squirrel.Update("reviewers").
+		Set("escalation_retry_at", retryAt).
+		Where(squirrel.Eq{
+			"tenant_id":       tenant.FromContext(ctx),
+			"pull_request_id": pullRequestID,
+			"user_id":         reviewerID,
+			"unassigned_at":   nil,
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build defer escalation query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("defer escalation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) RecordEscalation(ctx context.Context, escalation model.ReviewEscalation) error {
+	sql, args, err := squirrel.Insert("review_escalations").
+		Columns("tenant_id", "pull_request_id", "old_reviewer_id", "new_reviewer_id", "reason").
+		Values(tenant.FromContext(ctx), escalation.PullRequestID, escalation.OldReviewerID, escalation.NewReviewerID, escalation.Reason).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build record escalation query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("record escalation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package rejection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RejectionRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func NewRejectionRepository(pgxpool *pgxpool.Pool) *RejectionRepository {
+	return &RejectionRepository{pgxpool: pgxpool}
+}
+
+func (r *RejectionRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
+func (r *RejectionRepository) SaveRejection(ctx context.Context, rejection *model.IntegrationRejection) (*model.IntegrationRejection, error) {
+	sql, args, err := squirrel.Insert("integration_rejections").
+		Columns("tenant_id", "provider", "action", "pull_request_id", "external_login", "reason", "payload").
+		Values(
+			tenant.FromContext(ctx),
+			string(rejection.Provider),
+			string(rejection.Action),
+			rejection.PullRequestID,
+			rejection.ExternalLogin,
+			rejection.Reason,
+			rejection.Payload,
+		).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build save rejection query: %w", err)
+	}
+
+	saved := *rejection
+	if err := r.conn(ctx).QueryRow(ctx, sql, args...).Scan(&saved.ID, &saved.CreatedAt); err != nil {
+		return nil, fmt.Errorf("save rejection: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func (r *RejectionRepository) ListRejections(ctx context.Context, provider model.ExternalProvider, limit int) ([]model.IntegrationRejection, error) {
+	builder := squirrel.Select("id", "provider", "action", "pull_request_id", "external_login", "reason", "payload", "created_at").
+		From("integration_rejections").
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx)}).
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+	if provider != "" {
+		builder = builder.Where(squirrel.Eq{"provider": string(provider)})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list rejections query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query rejections: %w", err)
+	}
+	defer rows.Close()
+
+	rejections := []model.IntegrationRejection{}
+	for rows.Next() {
+		var rejection model.IntegrationRejection
+		if err := rows.Scan(
+			&rejection.ID,
+			&rejection.Provider,
+			&rejection.Action,
+			&rejection.PullRequestID,
+			&rejection.ExternalLogin,
+			&rejection.Reason,
+			&rejection.Payload,
+			&rejection.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan rejection: %w", err)
+		}
+		rejections = append(rejections, rejection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rejections rows error: %w", err)
+	}
+
+	return rejections, nil
+}
@@ -0,0 +1,220 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatsRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func NewStatsRepository(pgxpool *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{pgxpool: pgxpool}
+}
+
+func (r *StatsRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
+func (r *StatsRepository) GetUserAssignmentStats(ctx context.Context, filter model.StatsFilter) ([]model.UserAssignmentStats, error) {
+	join, joinArgs := pullRequestsJoin(filter)
+	sql, args, err := squirrel.Select("u.user_id", "u.username", "u.team_name").
+		Columns(assignmentCountColumns()...).
+		From("users u").
+		LeftJoin("reviewers r ON r.tenant_id = u.tenant_id AND r.user_id = u.user_id").
+		LeftJoin(join, joinArgs...).
+		Where(squirrel.Eq{"u.tenant_id": tenant.FromContext(ctx)}).
+		GroupBy("u.user_id", "u.username", "u.team_name").
+		OrderBy("u.team_name", "u.user_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build user assignment stats query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query user assignment stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]model.UserAssignmentStats, 0)
+	for rows.Next() {
+		var s model.UserAssignmentStats
+		if err := rows.Scan(&s.UserID, &s.Username, &s.TeamName, &s.Total, &s.Open, &s.Merged, &s.Reassigned); err != nil {
+			return nil, fmt.Errorf("scan user assignment stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("user assignment stats rows error: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepository) GetTeamAssignmentStats(ctx context.Context, filter model.StatsFilter) ([]model.TeamAssignmentStats, error) {
+	join, joinArgs := pullRequestsJoin(filter)
+	sql, args, err := squirrel.Select("t.team_name").
+		Columns(assignmentCountColumns()...).
+		From("teams t").
+		LeftJoin("users u ON u.tenant_id = t.tenant_id AND u.team_name = t.team_name").
+		LeftJoin("reviewers r ON r.tenant_id = u.tenant_id AND r.user_id = u.user_id").
+		LeftJoin(join, joinArgs...).
+		Where(squirrel.Eq{"t.tenant_id": tenant.FromContext(ctx)}).
+		GroupBy("t.team_name").
+		OrderBy("t.team_name").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build team assignment stats query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query team assignment stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]model.TeamAssignmentStats, 0)
+	for rows.Next() {
+		var s model.TeamAssignmentStats
+		if err := rows.Scan(&s.TeamName, &s.Total, &s.Open, &s.Merged, &s.Reassigned); err != nil {
+			return nil, fmt.Errorf("scan team assignment stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("team assignment stats rows error: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepository) GetTeamPullRequestStats(ctx context.Context, filter model.StatsFilter, staleBefore time.Time) ([]model.TeamPullRequestStats, error) {
+	rows, err := r.queryPullRequestStats(ctx, []string{"u.team_name"}, filter, staleBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]model.TeamPullRequestStats, 0)
+	for rows.Next() {
+		var s model.TeamPullRequestStats
+		var median, p90 *float64
+		if err := rows.Scan(&s.TeamName, &s.MergedCount, &median, &p90, &s.StaleOpenCount); err != nil {
+			return nil, fmt.Errorf("scan team pull request stats: %w", err)
+		}
+		s.MedianTimeToMerge, s.P90TimeToMerge = secondsToDuration(median), secondsToDuration(p90)
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("team pull request stats rows error: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepository) GetAuthorPullRequestStats(ctx context.Context, filter model.StatsFilter, staleBefore time.Time) ([]model.AuthorPullRequestStats, error) {
+	rows, err := r.queryPullRequestStats(ctx, []string{"pr.author_id", "u.team_name"}, filter, staleBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]model.AuthorPullRequestStats, 0)
+	for rows.Next() {
+		var s model.AuthorPullRequestStats
+		var median, p90 *float64
+		if err := rows.Scan(&s.AuthorID, &s.TeamName, &s.MergedCount, &median, &p90, &s.StaleOpenCount); err != nil {
+			return nil, fmt.Errorf("scan author pull request stats: %w", err)
+		}
+		s.MedianTimeToMerge, s.P90TimeToMerge = secondsToDuration(median), secondsToDuration(p90)
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("author pull request stats rows error: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepository) queryPullRequestStats(ctx context.Context, groupColumns []string, filter model.StatsFilter, staleBefore time.Time) (pgx.Rows, error) {
+	merged := fmt.Sprintf("pr.status = '%s'", model.PullRequestMerged)
+	var mergedArgs []any
+	if filter.From != nil {
+		merged += " AND pr.merged_at >= ?"
+		mergedArgs = append(mergedArgs, *filter.From)
+	}
+	if filter.To != nil {
+		merged += " AND pr.merged_at < ?"
+		mergedArgs = append(mergedArgs, *filter.To)
+	}
+	timeToMerge := "EXTRACT(EPOCH FROM (pr.merged_at - pr.created_at))"
+
+	sql, args, err := squirrel.Select(groupColumns...).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE "+merged+")", mergedArgs...)).
+		Column(squirrel.Expr("percentile_cont(0.5) WITHIN GROUP (ORDER BY "+timeToMerge+") FILTER (WHERE "+merged+")", mergedArgs...)).
+		Column(squirrel.Expr("percentile_cont(0.9) WITHIN GROUP (ORDER BY "+timeToMerge+") FILTER (WHERE "+merged+")", mergedArgs...)).
+		Column(squirrel.Expr(fmt.Sprintf("COUNT(*) FILTER (WHERE pr.status = '%s' AND pr.created_at < ?)", model.PullRequestOpen), staleBefore)).
+		From("pull_requests pr").
+		Join("users u ON u.tenant_id = pr.tenant_id AND u.user_id = pr.author_id").
+		Where(squirrel.Eq{"pr.tenant_id": tenant.FromContext(ctx)}).
+		GroupBy(groupColumns...).
+		OrderBy(groupColumns...).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build pull request stats query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pull request stats: %w", err)
+	}
+	return rows, nil
+}
+
+func secondsToDuration(seconds *float64) *time.Duration {
+	if seconds == nil {
+		return nil
+	}
+	d := time.Duration(*seconds * float64(time.Second))
+	return &d
+}
+
+func assignmentCountColumns() []string {
+	return []string{
+		"COUNT(pr.pull_request_id)",
+		fmt.Sprintf("COUNT(pr.pull_request_id) FILTER (WHERE r.unassigned_at IS NULL AND pr.status = '%s')", model.PullRequestOpen),
+		fmt.Sprintf("COUNT(pr.pull_request_id) FILTER (WHERE r.unassigned_at IS NULL AND pr.status = '%s')", model.PullRequestMerged),
+		"COUNT(pr.pull_request_id) FILTER (WHERE r.unassigned_at IS NOT NULL)",
+	}
+}
+
+func pullRequestsJoin(filter model.StatsFilter) (string, []any) {
+	join := "pull_requests pr ON pr.tenant_id = r.tenant_id AND pr.pull_request_id = r.pull_request_id"
+	var args []any
+	if filter.From != nil {
+		join += " AND pr.created_at >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		join += " AND pr.created_at < ?"
+		args = append(args, *filter.To)
+	}
+	return join, args
+}
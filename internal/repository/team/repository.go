@@ -7,6 +7,8 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,8 +22,12 @@ func NewTeamRepository(pgxpool *pgxpool.Pool) *TeamRepository {
 	return &TeamRepository{pgxpool: pgxpool}
 }
 
+func (r *TeamRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
 func (r *TeamRepository) CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error) {
-	tx, err := r.pgxpool.Begin(ctx)
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +51,28 @@ func (r *TeamRepository) CreateTeam(ctx context.Context, team *model.Team) (*mod
 
 func insertTeam(ctx context.Context, team *model.Team, tx pgx.Tx) error {
 	sql, args, err := squirrel.Insert("teams").
-		Columns("team_name").
-		Values(team.TeamName).
+		Columns(
+			"tenant_id",
+			"team_name",
+			"reviewer_strategy",
+			"min_reviewers",
+			"max_reviewers",
+			"min_approvals",
+			"block_on_changes_requested",
+			"author_cannot_merge",
+			"review_sla_hours",
+		).
+		Values(
+			tenant.FromContext(ctx),
+			team.TeamName,
+			team.Settings.ReviewerStrategy,
+			team.Settings.MinReviewers,
+			team.Settings.MaxReviewers,
+			team.Settings.MergePolicy.MinApprovals,
+			team.Settings.MergePolicy.BlockOnChangesRequested,
+			team.Settings.MergePolicy.AuthorCannotMerge,
+			team.Settings.ReviewSLAHours,
+		).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -58,25 +84,31 @@ func insertTeam(ctx context.Context, team *model.Team, tx pgx.Tx) error {
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return model.ErrTeamExists
 		}
+		return err
 	}
 	return nil
 }
 
 func insertUsers(ctx context.Context, team *model.Team, tx pgx.Tx) error {
 	if len(team.Users) > 0 {
+		tenantID := tenant.FromContext(ctx)
 		builder := squirrel.Insert("users").
-			Columns("team_name", "user_id", "username", "is_active").
+			Columns("tenant_id", "team_name", "user_id", "username", "is_active").
 			PlaceholderFormat(squirrel.Dollar)
 		for _, user := range team.Users {
-			builder = builder.Values(team.TeamName, user.UserID, user.Username, user.IsActive)
+			builder = builder.Values(tenantID, team.TeamName, user.UserID, user.Username, user.IsActive)
 		}
-		builder = builder.Suffix("ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active")
+		builder = builder.Suffix("ON CONFLICT (tenant_id, user_id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active")
 		sql, args, err := builder.ToSql()
 		if err != nil {
 			return err
 		}
 		_, err = tx.Exec(ctx, sql, args...)
 		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return model.ErrUsernameTaken
+			}
 			return err
 		}
 	}
@@ -84,16 +116,25 @@ func insertUsers(ctx context.Context, team *model.Team, tx pgx.Tx) error {
 }
 
 func (r *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*model.Team, error) {
-	sqlBuilder, args, err := squirrel.Select("t.team_name", "u.user_id", "u.username", "u.is_active").
+	sqlBuilder, args, err := squirrel.Select(
+		"t.team_name",
+		"t.reviewer_strategy",
+		"t.min_reviewers",
+		"t.max_reviewers",
+		"t.min_approvals",
+		"t.block_on_changes_requested",
+		"t.author_cannot_merge",
+		"t.review_sla_hours",
+		"u.user_id", "u.username", "u.is_active").
 		From("teams t").
-		LeftJoin("users u ON t.team_name = u.team_name").
-		Where(squirrel.Eq{"t.team_name": teamName}).
+		LeftJoin("users u ON u.tenant_id = t.tenant_id AND u.team_name = t.team_name").
+		Where(squirrel.Eq{"t.tenant_id": tenant.FromContext(ctx), "t.team_name": teamName}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
-	rows, err := r.pgxpool.Query(ctx, sqlBuilder, args...)
+	rows, err := r.conn(ctx).Query(ctx, sqlBuilder, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -107,14 +148,28 @@ func (r *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*m
 		var isActive sql.NullBool
 
 		var scannedTeamName string
+		var settings model.TeamSettings
 
-		err := rows.Scan(&scannedTeamName, &userID, &username, &isActive)
+		err := rows.Scan(
+			&scannedTeamName,
+			&settings.ReviewerStrategy,
+			&settings.MinReviewers,
+			&settings.MaxReviewers,
+			&settings.MergePolicy.MinApprovals,
+			&settings.MergePolicy.BlockOnChangesRequested,
+			&settings.MergePolicy.AuthorCannotMerge,
+			&settings.ReviewSLAHours,
+			&userID,
+			&username,
+			&isActive,
+		)
 		if err != nil {
 			return nil, err
 		}
 
 		if !teamFound {
 			team.TeamName = scannedTeamName
+			team.Settings = settings
 			teamFound = true
 		}
 
@@ -137,3 +192,135 @@ func (r *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*m
 	}
 	return &team, nil
 }
+
+func (r *TeamRepository) LockSettings(ctx context.Context, teamName string) (*model.TeamSettings, error) {
+	sql, args, err := squirrel.Select(
+		"reviewer_strategy",
+		"min_reviewers",
+		"max_reviewers",
+		"min_approvals",
+		"block_on_changes_requested",
+		"author_cannot_merge",
+		"review_sla_hours",
+	).
+		From("teams").
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "team_name": teamName}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var settings model.TeamSettings
+	err = r.conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&settings.ReviewerStrategy,
+		&settings.MinReviewers,
+		&settings.MaxReviewers,
+		&settings.MergePolicy.MinApprovals,
+		&settings.MergePolicy.BlockOnChangesRequested,
+		&settings.MergePolicy.AuthorCannotMerge,
+		&settings.ReviewSLAHours,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrTeamNotFound
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *TeamRepository) UpdateSettings(ctx context.Context, teamName string, settings model.TeamSettings) error {
+	sql, args, err := squirrel.Update("teams").
+		Set("reviewer_strategy", settings.ReviewerStrategy).
+		Set("min_reviewers", settings.MinReviewers).
+		Set("max_reviewers", settings.MaxReviewers).
+		Set("min_approvals", settings.MergePolicy.MinApprovals).
+		Set("block_on_changes_requested", settings.MergePolicy.BlockOnChangesRequested).
+		Set("author_cannot_merge", settings.MergePolicy.AuthorCannotMerge).
+		Set("review_sla_hours", settings.ReviewSLAHours).
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "team_name": teamName}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return model.ErrTeamNotFound
+	}
+	return nil
+}
+
+func (r *TeamRepository) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) ([]model.User, error) {
+	sql, args, err := squirrel.Update("users").
+		Set("is_active", false).
+		Where(squirrel.Eq{
+			"tenant_id": tenant.FromContext(ctx),
+			"team_name": teamName,
+			"user_id":   userIDs,
+		}).
+		Suffix("RETURNING user_id, username, team_name, is_active").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]model.User, 0, len(userIDs))
+	for rows.Next() {
+		var user model.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *TeamRepository) ListTenants(ctx context.Context) ([]string, error) {
+	sql, args, err := squirrel.Select("DISTINCT tenant_id").
+		From("teams").
+		OrderBy("tenant_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenantIDs []string
+	for rows.Next() {
+		var tenantID string
+		if err := rows.Scan(&tenantID); err != nil {
+			return nil, err
+		}
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tenantIDs, nil
+}
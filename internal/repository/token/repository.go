@@ -0,0 +1,151 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TokenRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func NewTokenRepository(pgxpool *pgxpool.Pool) *TokenRepository {
+	return &TokenRepository{pgxpool: pgxpool}
+}
+
+func (r *TokenRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
+func (r *TokenRepository) CreateToken(ctx context.Context, token *model.APIToken, tokenHash string) (*model.APIToken, error) {
+	var userID, tenantID *string
+	if token.UserID != "" {
+		userID = &token.UserID
+		scoped := tenant.FromContext(ctx)
+		tenantID = &scoped
+	}
+
+	sql, args, err := squirrel.Insert("api_tokens").
+		Columns("name", "user_id", "tenant_id", "role", "token_hash", "expires_at").
+		Values(token.Name, userID, tenantID, string(token.Role), tokenHash, token.ExpiresAt).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build create token query: %w", err)
+	}
+
+	created := *token
+	if tenantID != nil {
+		created.TenantID = *tenantID
+	}
+	if err := r.conn(ctx).QueryRow(ctx, sql, args...).Scan(&created.ID, &created.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("create token: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *TokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*model.APIToken, error) {
+	sql, args, err := tokensQuery().
+		Where(squirrel.Eq{"t.token_hash": tokenHash}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build get token query: %w", err)
+	}
+
+	token, err := scanToken(r.conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *TokenRepository) ListTokens(ctx context.Context) ([]model.APIToken, error) {
+	sql, args, err := tokensQuery().OrderBy("t.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list tokens query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []model.APIToken{}
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan token: %w", err)
+		}
+		tokens = append(tokens, *token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("tokens rows error: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (r *TokenRepository) RevokeToken(ctx context.Context, id int64) error {
+	sql, args, err := squirrel.Update("api_tokens").
+		Set("revoked_at", squirrel.Expr("COALESCE(revoked_at, now())")).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build revoke token query: %w", err)
+	}
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return model.ErrTokenNotFound
+	}
+	return nil
+}
+
+func tokensQuery() squirrel.SelectBuilder {
+	return squirrel.Select(
+		"t.id",
+		"t.name",
+		"COALESCE(t.user_id, '')",
+		"t.role",
+		"COALESCE(t.tenant_id, '')",
+		"t.created_at",
+		"t.expires_at",
+		"t.revoked_at",
+	).
+		From("api_tokens t").
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func scanToken(row pgx.Row) (*model.APIToken, error) {
+	var token model.APIToken
+	if err := row.Scan(&token.ID, &token.Name, &token.UserID, &token.Role, &token.TenantID, &token.CreatedAt, &token.ExpiresAt, &token.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -19,10 +21,14 @@ func NewUserRepository(pgxPool *pgxpool.Pool) *UserRepository {
 	return &UserRepository{pgxPool: pgxPool}
 }
 
+func (r *UserRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxPool)
+}
+
 func (r *UserRepository) UpdateStatus(ctx context.Context, userId string, isActive bool) (*model.User, error) {
 	quary, args, err := squirrel.Update("users").
 		Set("is_active", isActive).
-		Where(squirrel.Eq{"user_id": userId}).
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "user_id": userId}).
 		Suffix("RETURNING user_id, username, team_name, is_active").
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
@@ -31,7 +37,7 @@ func (r *UserRepository) UpdateStatus(ctx context.Context, userId string, isActi
 	}
 
 	var user model.User
-	err = r.pgxPool.QueryRow(ctx, quary, args...).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	err = r.conn(ctx).QueryRow(ctx, quary, args...).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -46,7 +52,7 @@ func (r *UserRepository) UpdateStatus(ctx context.Context, userId string, isActi
 func (r *UserRepository) GetUserByID(ctx context.Context, userId string) (*model.User, error) {
 	query, args, err := squirrel.Select("user_id", "username", "team_name", "is_active").
 		From("users").
-		Where(squirrel.Eq{"user_id": userId}).
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "user_id": userId}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -54,7 +60,7 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userId string) (*model
 	}
 
 	var user model.User
-	err = r.pgxPool.QueryRow(ctx, query, args...).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, model.ErrUserNotFound
@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/storage"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pgxpool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pgxpool: pgxpool}
+}
+
+func (r *WebhookRepository) conn(ctx context.Context) storage.Executor {
+	return storage.Conn(ctx, r.pgxpool)
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	sql, args, err := squirrel.Insert("webhook_subscriptions").
+		Columns("tenant_id", "url", "secret", "events", "is_active").
+		Values(tenant.FromContext(ctx), sub.URL, sub.Secret, eventsToStrings(sub.Events), sub.IsActive).
+		Suffix("RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build create subscription query: %w", err)
+	}
+
+	created := *sub
+	if err := r.conn(ctx).QueryRow(ctx, sql, args...).Scan(&created.ID, &created.CreatedAt); err != nil {
+		return nil, fmt.Errorf("create subscription: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error) {
+	sql, args, err := subscriptionsQuery().
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build get subscription query: %w", err)
+	}
+
+	sub, err := scanSubscription(r.conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("get subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	sql, args, err := subscriptionsQuery().
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx)}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list subscriptions query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := []model.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan subscription: %w", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("subscriptions rows error: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	builder := squirrel.Update("webhook_subscriptions").
+		Set("url", sub.URL).
+		Set("events", eventsToStrings(sub.Events)).
+		Set("is_active", sub.IsActive).
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "id": sub.ID}).
+		PlaceholderFormat(squirrel.Dollar)
+	if sub.Secret != "" {
+		builder = builder.Set("secret", sub.Secret)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("build update subscription query: %w", err)
+	}
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("update subscription: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return model.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	sql, args, err := squirrel.Delete("webhook_subscriptions").
+		Where(squirrel.Eq{"tenant_id": tenant.FromContext(ctx), "id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build delete subscription query: %w", err)
+	}
+
+	result, err := r.conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("delete subscription: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return model.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, eventType model.EventType, payload []byte) error {
+	sql := "INSERT INTO webhook_deliveries (tenant_id, subscription_id, event_type, payload) " +
+		"SELECT tenant_id, id, $1, $2 FROM webhook_subscriptions WHERE tenant_id = $3 AND is_active AND $1 = ANY(events)"
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, string(eventType), payload, tenant.FromContext(ctx)); err != nil {
+		return fmt.Errorf("enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) ClaimDelivery(ctx context.Context, lease time.Duration) (*model.WebhookDelivery, error) {
+	sql := "UPDATE webhook_deliveries d SET attempts = d.attempts + 1, next_attempt_at = now() + make_interval(secs => $1) " +
+		"FROM webhook_subscriptions s " +
+		"WHERE s.id = d.subscription_id AND d.id = (" +
+		"SELECT id FROM webhook_deliveries " +
+		"WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= now() " +
+		"ORDER BY next_attempt_at LIMIT 1 FOR UPDATE SKIP LOCKED) " +
+		"RETURNING d.id, d.subscription_id, s.url, s.secret, d.event_type, d.payload, d.attempts"
+
+	var delivery model.WebhookDelivery
+	err := r.conn(ctx).QueryRow(ctx, sql, lease.Seconds()).Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.URL,
+		&delivery.Secret,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Attempts,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64) error {
+	sql, args, err := squirrel.Update("webhook_deliveries").
+		Set("delivered_at", time.Now()).
+		Set("last_error", nil).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build mark delivered query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("mark delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error {
+	builder := squirrel.Update("webhook_deliveries").
+		Set("last_error", lastError).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar)
+	if nextAttemptAt != nil {
+		builder = builder.Set("next_attempt_at", *nextAttemptAt)
+	} else {
+		builder = builder.Set("failed_at", time.Now())
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("build mark failed query: %w", err)
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("mark failed: %w", err)
+	}
+	return nil
+}
+
+func subscriptionsQuery() squirrel.SelectBuilder {
+	return squirrel.Select("id", "url", "secret", "events", "is_active", "created_at").
+		From("webhook_subscriptions").
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func scanSubscription(row pgx.Row) (*model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	var events []string
+	if err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.IsActive, &sub.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	sub.Events = make([]model.EventType, 0, len(events))
+	for _, event := range events {
+		sub.Events = append(sub.Events, model.EventType(event))
+	}
+	return &sub, nil
+}
+
+func eventsToStrings(events []model.EventType) []string {
+	result := make([]string, 0, len(events))
+	for _, event := range events {
+		result = append(result, string(event))
+	}
+	return result
+}
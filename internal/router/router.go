@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/avito/internship/pr-service/internal/handler"
+	"github.com/avito/internship/pr-service/internal/metrics"
+	"github.com/avito/internship/pr-service/internal/tenant"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 )
@@ -11,6 +13,8 @@ import (
 type TeamController interface {
 	CreateTeam(w http.ResponseWriter, r *http.Request) error
 	GetTeamByName(w http.ResponseWriter, r *http.Request) error
+	SetSettings(w http.ResponseWriter, r *http.Request) error
+	DeactivateUsers(w http.ResponseWriter, r *http.Request) error
 }
 
 type UserController interface {
@@ -21,33 +25,127 @@ type UserController interface {
 type PullRequestController interface {
 	Create(w http.ResponseWriter, r *http.Request) error
 	Merge(w http.ResponseWriter, r *http.Request) error
+	Close(w http.ResponseWriter, r *http.Request) error
+	Reopen(w http.ResponseWriter, r *http.Request) error
+	MarkReady(w http.ResponseWriter, r *http.Request) error
+	Review(w http.ResponseWriter, r *http.Request) error
+	GetOverdueReviews(w http.ResponseWriter, r *http.Request) error
+	GetHistory(w http.ResponseWriter, r *http.Request) error
 	Reassign(w http.ResponseWriter, r *http.Request) error
 }
 
+type StatsController interface {
+	GetAssignmentStats(w http.ResponseWriter, r *http.Request) error
+	GetPullRequestStats(w http.ResponseWriter, r *http.Request) error
+}
+
+type WebhookController interface {
+	Create(w http.ResponseWriter, r *http.Request) error
+	Get(w http.ResponseWriter, r *http.Request) error
+	List(w http.ResponseWriter, r *http.Request) error
+	Update(w http.ResponseWriter, r *http.Request) error
+	Delete(w http.ResponseWriter, r *http.Request) error
+}
+
+type IntegrationController interface {
+	GitHubWebhook(w http.ResponseWriter, r *http.Request) error
+	GitLabWebhook(w http.ResponseWriter, r *http.Request) error
+	ListRejections(w http.ResponseWriter, r *http.Request) error
+	SaveIdentity(w http.ResponseWriter, r *http.Request) error
+	ListIdentities(w http.ResponseWriter, r *http.Request) error
+	DeleteIdentity(w http.ResponseWriter, r *http.Request) error
+}
+
+type AuditController interface {
+	List(w http.ResponseWriter, r *http.Request) error
+}
+
+type TokenController interface {
+	Issue(w http.ResponseWriter, r *http.Request) error
+	List(w http.ResponseWriter, r *http.Request) error
+	Revoke(w http.ResponseWriter, r *http.Request) error
+}
+
 func InitRouter() *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
+	r.Use(metrics.Middleware)
+	r.Use(tenant.Middleware)
+	r.Handle("/metrics", metrics.Handler())
 	return r
 }
 
-func SetupTeamRoutes(r *chi.Mux, c TeamController) {
+func SetupTeamRoutes(r chi.Router, c TeamController) {
 	r.Route("/team", func(r chi.Router) {
 		r.Post("/add", handler.ErrorHandler(c.CreateTeam))
 		r.Get("/get", handler.ErrorHandler(c.GetTeamByName))
+		r.Post("/setSettings", handler.ErrorHandler(c.SetSettings))
+		r.Post("/deactivateUsers", handler.ErrorHandler(c.DeactivateUsers))
 	})
 }
 
-func SetupUserRoutes(r *chi.Mux, c UserController) {
+func SetupUserRoutes(r chi.Router, c UserController) {
 	r.Route("/users", func(r chi.Router) {
 		r.Post("/setIsActive", handler.ErrorHandler(c.SetIsActive))
 		r.Get("/getReview", handler.ErrorHandler(c.GetUsersPullRequests))
 	})
 }
 
-func SetupPullRequestRoutes(r *chi.Mux, c PullRequestController) {
+func SetupPullRequestRoutes(r chi.Router, c PullRequestController) {
 	r.Route("/pullRequest", func(r chi.Router) {
 		r.Post("/create", handler.ErrorHandler(c.Create))
 		r.Post("/merge", handler.ErrorHandler(c.Merge))
+		r.Post("/close", handler.ErrorHandler(c.Close))
+		r.Post("/reopen", handler.ErrorHandler(c.Reopen))
+		r.Post("/markReady", handler.ErrorHandler(c.MarkReady))
+		r.Post("/review", handler.ErrorHandler(c.Review))
+		r.Get("/overdue", handler.ErrorHandler(c.GetOverdueReviews))
+		r.Get("/history", handler.ErrorHandler(c.GetHistory))
 		r.Post("/reassign", handler.ErrorHandler(c.Reassign))
 	})
 }
+
+func SetupStatsRoutes(r chi.Router, c StatsController) {
+	r.Route("/stats", func(r chi.Router) {
+		r.Get("/assignments", handler.ErrorHandler(c.GetAssignmentStats))
+		r.Get("/pullRequests", handler.ErrorHandler(c.GetPullRequestStats))
+	})
+}
+
+func SetupWebhookRoutes(r chi.Router, c WebhookController) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Post("/add", handler.ErrorHandler(c.Create))
+		r.Get("/get", handler.ErrorHandler(c.Get))
+		r.Get("/list", handler.ErrorHandler(c.List))
+		r.Post("/update", handler.ErrorHandler(c.Update))
+		r.Post("/delete", handler.ErrorHandler(c.Delete))
+	})
+}
+
+func SetupIntegrationRoutes(r chi.Router, c IntegrationController, authenticate func(http.Handler) http.Handler) {
+	r.Route("/integrations", func(r chi.Router) {
+		r.Post("/github/webhook", handler.ErrorHandler(c.GitHubWebhook))
+		r.Post("/gitlab/webhook", handler.ErrorHandler(c.GitLabWebhook))
+		r.Post("/{tenant}/github/webhook", handler.ErrorHandler(c.GitHubWebhook))
+		r.Post("/{tenant}/gitlab/webhook", handler.ErrorHandler(c.GitLabWebhook))
+		r.Group(func(r chi.Router) {
+			r.Use(authenticate)
+			r.Get("/rejections", handler.ErrorHandler(c.ListRejections))
+			r.Post("/identities/add", handler.ErrorHandler(c.SaveIdentity))
+			r.Get("/identities/list", handler.ErrorHandler(c.ListIdentities))
+			r.Post("/identities/delete", handler.ErrorHandler(c.DeleteIdentity))
+		})
+	})
+}
+
+func SetupAuditRoutes(r chi.Router, c AuditController) {
+	r.Get("/audit", handler.ErrorHandler(c.List))
+}
+
+func SetupTokenRoutes(r chi.Router, c TokenController) {
+	r.Route("/tokens", func(r chi.Router) {
+		r.Post("/issue", handler.ErrorHandler(c.Issue))
+		r.Get("/list", handler.ErrorHandler(c.List))
+		r.Post("/revoke", handler.ErrorHandler(c.Revoke))
+	})
+}
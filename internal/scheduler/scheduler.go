@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(jobs ...Job) *Scheduler {
+	return &Scheduler{jobs: jobs}
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			log.Printf("job %q disabled: non-positive interval", job.Name)
+			continue
+		}
+		s.wg.Add(1)
+		go s.runJob(ctx, job)
+	}
+	log.Println("scheduler started")
+}
+
+func (s *Scheduler) Stop() {
+	log.Println("stopping scheduler...")
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	log.Println("scheduler stopped")
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("job %q failed: %v", job.Name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditRepository struct {
+	mock.Mock
+}
+
+func (m *AuditRepository) Add(ctx context.Context, event model.AuditEvent, before, after []byte) error {
+	args := m.Called(ctx, event, before, after)
+	return args.Error(0)
+}
+
+func (m *AuditRepository) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.AuditEvent), args.Error(1)
+}
@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type AuditRepository interface {
+	Add(ctx context.Context, event model.AuditEvent, before, after []byte) error
+	List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, error)
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/avito/internship/pr-service/internal/actor"
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+)
+
+type AuditService struct {
+	auditRepository AuditRepository
+}
+
+func NewAuditService(auditRepository AuditRepository) *AuditService {
+	return &AuditService{auditRepository: auditRepository}
+}
+
+func (s *AuditService) Record(ctx context.Context, event model.AuditEvent) error {
+	if event.Actor == "" {
+		event.Actor = actor.FromContext(ctx)
+	}
+
+	before, err := encodeSnapshot(event.Before)
+	if err != nil {
+		return fmt.Errorf("encode before snapshot: %w", err)
+	}
+	after, err := encodeSnapshot(event.After)
+	if err != nil {
+		return fmt.Errorf("encode after snapshot: %w", err)
+	}
+
+	return s.auditRepository.Add(ctx, event, before, after)
+}
+
+func (s *AuditService) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEvent, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, model.ErrInvalidTimeRange
+	}
+	if filter.Action != "" && !filter.Action.IsValid() {
+		return nil, model.ErrInvalidAuditAction
+	}
+	if filter.Limit < 0 || filter.Limit > maxListLimit {
+		return nil, model.ErrInvalidLimit
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultListLimit
+	}
+
+	events, err := s.auditRepository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list audit events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,156 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/actor"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/audit"
+	"github.com/avito/internship/pr-service/internal/service/audit/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	mergedBy := "user2"
+	before := &model.PullRequest{PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "user1", Status: model.PullRequestOpen, AssignedReviewers: []string{"user2"}}
+	after := &model.PullRequest{PullRequestID: "pr1", PullRequestName: "Fix", AuthorID: "user1", Status: model.PullRequestMerged, AssignedReviewers: []string{"user2"}, MergedBy: &mergedBy}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		event          model.AuditEvent
+		expectedActor  string
+		expectedBefore string
+		expectedAfter  string
+		repositoryErr  error
+		expectedError  string
+	}{
+		{
+			name:           "Success - actor taken from context",
+			ctx:            actor.WithActor(context.Background(), "user2"),
+			event:          model.AuditEvent{Action: model.AuditActionMerge, TargetType: model.AuditTargetPullRequest, TargetID: "pr1", Before: before, After: after},
+			expectedActor:  "user2",
+			expectedBefore: `{"pull_request_id":"pr1","pull_request_name":"Fix","author_id":"user1","status":"OPEN","assigned_reviewers":["user2"]}`,
+			expectedAfter:  `{"pull_request_id":"pr1","pull_request_name":"Fix","author_id":"user1","status":"MERGED","assigned_reviewers":["user2"],"merged_by":"user2"}`,
+		},
+		{
+			name:          "Success - system actor and no before snapshot",
+			ctx:           context.Background(),
+			event:         model.AuditEvent{Action: model.AuditActionSetActive, TargetType: model.AuditTargetUser, TargetID: "user1", After: &model.User{UserID: "user1", Username: "Alice", TeamName: "backend"}},
+			expectedActor: actor.System,
+			expectedAfter: `{"user_id":"user1","username":"Alice","team_name":"backend","is_active":false}`,
+		},
+		{
+			name:           "Error on add",
+			ctx:            context.Background(),
+			event:          model.AuditEvent{Actor: "admin", Action: model.AuditActionReassign, TargetType: model.AuditTargetPullRequest, TargetID: "pr1", Before: map[string]string{"reviewer_id": "user2"}},
+			expectedActor:  "admin",
+			expectedBefore: `{"reviewer_id":"user2"}`,
+			repositoryErr:  errors.New("db error"),
+			expectedError:  "db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditRepository := new(mocks.AuditRepository)
+			auditRepository.On("Add", test.ctx, mock.AnythingOfType("model.AuditEvent"), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				assert.Equal(t, test.expectedActor, args.Get(1).(model.AuditEvent).Actor)
+				assertJSON(t, test.expectedBefore, args.Get(2).([]byte))
+				assertJSON(t, test.expectedAfter, args.Get(3).([]byte))
+			}).Return(test.repositoryErr).Once()
+
+			s := audit.NewAuditService(auditRepository)
+			err := s.Record(test.ctx, test.event)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			auditRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditService_List(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+	events := []model.AuditEvent{{ID: 1, Actor: "admin", Action: model.AuditActionCreate, TargetType: model.AuditTargetPullRequest, TargetID: "pr1"}}
+
+	tests := []struct {
+		name           string
+		filter         model.AuditFilter
+		setupMocks     func(*mocks.AuditRepository)
+		expectedEvents []model.AuditEvent
+		expectedError  string
+	}{
+		{
+			name:   "Success - default limit",
+			filter: model.AuditFilter{PullRequestID: "pr1", From: &from, To: &to},
+			setupMocks: func(auditRepository *mocks.AuditRepository) {
+				auditRepository.On("List", ctx, model.AuditFilter{PullRequestID: "pr1", From: &from, To: &to, Limit: 100}).Return(events, nil).Once()
+			},
+			expectedEvents: events,
+		},
+		{
+			name:          "Error - from after to",
+			filter:        model.AuditFilter{From: &to, To: &from},
+			setupMocks:    func(auditRepository *mocks.AuditRepository) {},
+			expectedError: model.ErrInvalidTimeRange.Error(),
+		},
+		{
+			name:          "Error - unknown action",
+			filter:        model.AuditFilter{Action: "delete"},
+			setupMocks:    func(auditRepository *mocks.AuditRepository) {},
+			expectedError: model.ErrInvalidAuditAction.Error(),
+		},
+		{
+			name:          "Error - limit too large",
+			filter:        model.AuditFilter{Limit: 5000},
+			setupMocks:    func(auditRepository *mocks.AuditRepository) {},
+			expectedError: model.ErrInvalidLimit.Error(),
+		},
+		{
+			name:   "Error on list",
+			filter: model.AuditFilter{UserID: "user1", Limit: 10},
+			setupMocks: func(auditRepository *mocks.AuditRepository) {
+				auditRepository.On("List", ctx, model.AuditFilter{UserID: "user1", Limit: 10}).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "list audit events: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditRepository := new(mocks.AuditRepository)
+			test.setupMocks(auditRepository)
+
+			s := audit.NewAuditService(auditRepository)
+			result, err := s.List(ctx, test.filter)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedEvents, result)
+			}
+			auditRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func assertJSON(t *testing.T, expected string, actual []byte) {
+	t.Helper()
+	if expected == "" {
+		assert.Nil(t, actual)
+		return
+	}
+	assert.JSONEq(t, expected, string(actual))
+}
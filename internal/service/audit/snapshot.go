@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type pullRequestSnapshot struct {
+	PullRequestID     string                  `json:"pull_request_id"`
+	PullRequestName   string                  `json:"pull_request_name"`
+	AuthorID          string                  `json:"author_id"`
+	Status            model.PullRequestStatus `json:"status"`
+	AssignedReviewers []string                `json:"assigned_reviewers"`
+	MergedAt          *time.Time              `json:"merged_at,omitempty"`
+	MergedBy          *string                 `json:"merged_by,omitempty"`
+	MergeOverride     bool                    `json:"merge_override,omitempty"`
+}
+
+type userSnapshot struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+}
+
+type teamSnapshot struct {
+	TeamName         string                 `json:"team_name"`
+	Members          []userSnapshot         `json:"members"`
+	ReviewerStrategy model.ReviewerStrategy `json:"reviewer_strategy"`
+	MinReviewers     int                    `json:"min_reviewers"`
+	MaxReviewers     int                    `json:"max_reviewers"`
+	ReviewSLAHours   int                    `json:"review_sla_hours"`
+}
+
+func encodeSnapshot(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case *model.PullRequest:
+		return json.Marshal(pullRequestSnapshot{
+			PullRequestID:     v.PullRequestID,
+			PullRequestName:   v.PullRequestName,
+			AuthorID:          v.AuthorID,
+			Status:            v.Status,
+			AssignedReviewers: v.AssignedReviewers,
+			MergedAt:          v.MergedAt,
+			MergedBy:          v.MergedBy,
+			MergeOverride:     v.MergeOverride,
+		})
+	case *model.User:
+		return json.Marshal(newUserSnapshot(*v))
+	case *model.Team:
+		members := make([]userSnapshot, 0, len(v.Users))
+		for _, user := range v.Users {
+			members = append(members, newUserSnapshot(user))
+		}
+		return json.Marshal(teamSnapshot{
+			TeamName:         v.TeamName,
+			Members:          members,
+			ReviewerStrategy: v.Settings.ReviewerStrategy,
+			MinReviewers:     v.Settings.MinReviewers,
+			MaxReviewers:     v.Settings.MaxReviewers,
+			ReviewSLAHours:   v.Settings.ReviewSLAHours,
+		})
+	default:
+		return json.Marshal(v)
+	}
+}
+
+func newUserSnapshot(user model.User) userSnapshot {
+	return userSnapshot{
+		UserID:   user.UserID,
+		Username: user.Username,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
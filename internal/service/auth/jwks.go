@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+)
+
+const (
+	maxJWKSSize        = 1 << 20
+	p256CoordinateSize = 32
+)
+
+type KeySet map[string]JSONWebKey
+
+type JSONWebKey struct {
+	KeyID     string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func LoadJWKSFile(path string) (KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read jwks file: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+func FetchJWKS(ctx context.Context, client *http.Client, url string) (KeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build jwks request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("read jwks response: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+func ParseJWKS(data []byte) (KeySet, error) {
+	var document struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(KeySet, len(document.Keys))
+	for _, raw := range document.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJWK(raw)
+		if err != nil {
+			log.Printf("jwks: skip key %q: %v", raw.Kid, err)
+			continue
+		}
+		keys[raw.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no usable signing keys")
+	}
+	return keys, nil
+}
+
+func parseJWK(raw jwk) (JSONWebKey, error) {
+	key := JSONWebKey{KeyID: raw.Kid, Algorithm: raw.Alg}
+
+	switch raw.Kty {
+	case "RSA":
+		n, err := decodeBigInt(raw.N)
+		if err != nil {
+			return key, fmt.Errorf("decode modulus: %w", err)
+		}
+		e, err := decodeBigInt(raw.E)
+		if err != nil {
+			return key, fmt.Errorf("decode exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return key, errors.New("exponent is too large")
+		}
+		key.Key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		if raw.Crv != "P-256" {
+			return key, fmt.Errorf("unsupported curve %q", raw.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(raw.X)
+		if err != nil {
+			return key, fmt.Errorf("decode x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(raw.Y)
+		if err != nil {
+			return key, fmt.Errorf("decode y: %w", err)
+		}
+		if len(x) != p256CoordinateSize || len(y) != p256CoordinateSize {
+			return key, errors.New("invalid P-256 coordinate size")
+		}
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return key, err
+		}
+		key.Key = pub
+	default:
+		return key, fmt.Errorf("unsupported key type %q", raw.Kty)
+	}
+
+	return key, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, errors.New("value is empty")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+const (
+	algRS256    = "RS256"
+	algES256    = "ES256"
+	es256KeyLen = 32
+)
+
+type JWTOptions struct {
+	Audience           string
+	Issuer             string
+	UserClaim          string
+	RoleClaim          string
+	TenantClaim        string
+	Leeway             time.Duration
+	RefreshKeys        func(ctx context.Context) (KeySet, error)
+	MinRefreshInterval time.Duration
+}
+
+type JWTAuthenticator struct {
+	mu          sync.RWMutex
+	keys        KeySet
+	refreshMu   sync.Mutex
+	lastRefresh time.Time
+	options     JWTOptions
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func NewJWTAuthenticator(keys KeySet, options JWTOptions) (*JWTAuthenticator, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("jwt authenticator needs at least one key")
+	}
+	if options.Audience == "" {
+		return nil, errors.New("jwt audience is required")
+	}
+	if options.UserClaim == "" {
+		options.UserClaim = "sub"
+	}
+	return &JWTAuthenticator{keys: keys, options: options}, nil
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*model.Principal, error) {
+	claims, err := a.verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrUnauthenticated, err)
+	}
+
+	userID, _ := claims[a.options.UserClaim].(string)
+	if userID == "" {
+		return nil, fmt.Errorf("%w: claim %q is missing", model.ErrUnauthenticated, a.options.UserClaim)
+	}
+
+	role := model.RoleMember
+	if a.options.RoleClaim != "" {
+		if value, ok := claims[a.options.RoleClaim].(string); ok && model.Role(value).IsValid() {
+			role = model.Role(value)
+		}
+	}
+
+	var tenantID string
+	if a.options.TenantClaim != "" {
+		tenantID, _ = claims[a.options.TenantClaim].(string)
+	}
+
+	return &model.Principal{Subject: userID, UserID: userID, Role: role, TenantID: tenantID}, nil
+}
+
+func (a *JWTAuthenticator) verify(ctx context.Context, token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("decode header: %w", err)
+	}
+
+	key, err := a.key(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+	if err := verifySignature(header.Alg, key.Key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("decode claims: %w", err)
+	}
+	if err := a.validateClaims(claims, time.Now()); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (a *JWTAuthenticator) key(ctx context.Context, header jwtHeader) (JSONWebKey, error) {
+	if header.Alg != algRS256 && header.Alg != algES256 {
+		return JSONWebKey{}, fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+
+	key, ok := a.lookupKey(header.Kid)
+	if !ok && a.refreshKeys(ctx, header.Kid) {
+		key, ok = a.lookupKey(header.Kid)
+	}
+	if !ok {
+		return JSONWebKey{}, fmt.Errorf("unknown key %q", header.Kid)
+	}
+	if key.Algorithm != "" && key.Algorithm != header.Alg {
+		return JSONWebKey{}, fmt.Errorf("key %q does not allow %s", header.Kid, header.Alg)
+	}
+	return key, nil
+}
+
+func (a *JWTAuthenticator) lookupKey(kid string) (JSONWebKey, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	key, ok := a.keys[kid]
+	if !ok && kid == "" && len(a.keys) == 1 {
+		for _, only := range a.keys {
+			key, ok = only, true
+		}
+	}
+	return key, ok
+}
+
+func (a *JWTAuthenticator) refreshKeys(ctx context.Context, kid string) bool {
+	if a.options.RefreshKeys == nil {
+		return false
+	}
+
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	if _, ok := a.lookupKey(kid); ok {
+		return true
+	}
+	if time.Since(a.lastRefresh) < a.options.MinRefreshInterval {
+		return false
+	}
+	a.lastRefresh = time.Now()
+
+	keys, err := a.options.RefreshKeys(ctx)
+	if err != nil {
+		log.Printf("jwks: refresh keys for kid %q: %v", kid, err)
+		return false
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.mu.Unlock()
+	return true
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case algRS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match RS256")
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("invalid signature")
+		}
+	case algES256:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match ES256")
+		}
+		if len(signature) != 2*es256KeyLen {
+			return errors.New("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:es256KeyLen])
+		s := new(big.Int).SetBytes(signature[es256KeyLen:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return errors.New("invalid signature")
+		}
+	}
+	return nil
+}
+
+func (a *JWTAuthenticator) validateClaims(claims map[string]any, now time.Time) error {
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return errors.New("exp claim is missing")
+	}
+	if !now.Before(exp.Add(a.options.Leeway)) {
+		return errors.New("token is expired")
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(a.options.Leeway).Before(nbf) {
+		return errors.New("token is not valid yet")
+	}
+
+	if !hasAudience(claims["aud"], a.options.Audience) {
+		return errors.New("audience does not match")
+	}
+	if a.options.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != a.options.Issuer {
+			return errors.New("issuer does not match")
+		}
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func numericDate(value any) (time.Time, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
+
+func hasAudience(value any, audience string) bool {
+	switch aud := value.(type) {
+	case string:
+		return aud == audience
+	case []any:
+		for _, item := range aud {
+			if item == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
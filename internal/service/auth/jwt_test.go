@@ -0,0 +1,347 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	keys, err := auth.LoadJWKSFile(writeJWKS(t, rsaKey, ecKey))
+	assert.NoError(t, err)
+
+	authenticator, err := auth.NewJWTAuthenticator(keys, auth.JWTOptions{
+		Audience:    "pr-service",
+		Issuer:      "https://sso.example.com",
+		UserClaim:   "preferred_username",
+		RoleClaim:   "role",
+		TenantClaim: "tenant_id",
+	})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"iss":                "https://sso.example.com",
+			"aud":                []string{"other", "pr-service"},
+			"exp":                now.Add(time.Hour).Unix(),
+			"preferred_username": "user1",
+		}
+	}
+	withClaim := func(name string, value any) map[string]any {
+		claims := validClaims()
+		claims[name] = value
+		return claims
+	}
+
+	tests := []struct {
+		name              string
+		token             string
+		expectedPrincipal *model.Principal
+	}{
+		{
+			name:              "Success - RS256",
+			token:             signRS256(t, rsaKey, "rsa-key", validClaims()),
+			expectedPrincipal: &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleMember},
+		},
+		{
+			name:              "Success - ES256 with role claim",
+			token:             signES256(t, ecKey, "ec-key", withClaim("role", "lead")),
+			expectedPrincipal: &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleLead},
+		},
+		{
+			name:              "Success - tenant claim",
+			token:             signRS256(t, rsaKey, "rsa-key", withClaim("tenant_id", "payments")),
+			expectedPrincipal: &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleMember, TenantID: "payments"},
+		},
+		{
+			name:              "Success - unknown role falls back to member",
+			token:             signRS256(t, rsaKey, "rsa-key", withClaim("role", "root")),
+			expectedPrincipal: &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleMember},
+		},
+		{
+			name:  "Error - expired token",
+			token: signRS256(t, rsaKey, "rsa-key", withClaim("exp", now.Add(-time.Minute).Unix())),
+		},
+		{
+			name:  "Error - missing exp",
+			token: signRS256(t, rsaKey, "rsa-key", withClaim("exp", nil)),
+		},
+		{
+			name:  "Error - wrong audience",
+			token: signRS256(t, rsaKey, "rsa-key", withClaim("aud", "other")),
+		},
+		{
+			name:  "Error - wrong issuer",
+			token: signRS256(t, rsaKey, "rsa-key", withClaim("iss", "https://evil.example.com")),
+		},
+		{
+			name:  "Error - missing user claim",
+			token: signRS256(t, rsaKey, "rsa-key", withClaim("preferred_username", "")),
+		},
+		{
+			name:  "Error - signed by unknown key",
+			token: signRS256(t, otherKey, "rsa-key", validClaims()),
+		},
+		{
+			name:  "Error - unknown kid",
+			token: signRS256(t, rsaKey, "missing", validClaims()),
+		},
+		{
+			name:  "Error - algorithm does not match key",
+			token: signRS256(t, rsaKey, "ec-key", validClaims()),
+		},
+		{
+			name:  "Error - unsigned token",
+			token: encodeSegment(t, map[string]any{"alg": "none", "kid": "rsa-key"}) + "." + encodeSegment(t, validClaims()) + ".",
+		},
+		{
+			name:  "Error - malformed token",
+			token: "not-a-jwt",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := authenticator.Authenticate(context.Background(), test.token)
+
+			if test.expectedPrincipal == nil {
+				assert.ErrorIs(t, err, model.ErrUnauthenticated)
+				assert.Nil(t, p)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedPrincipal, p)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticator_RefreshesKeysOnUnknownKid(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rotatedKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	claims := map[string]any{"aud": "pr-service", "exp": time.Now().Add(time.Hour).Unix(), "sub": "user1"}
+	keys := auth.KeySet{"old": {KeyID: "old", Algorithm: "RS256", Key: &oldKey.PublicKey}}
+	rotated := auth.KeySet{
+		"old":     {KeyID: "old", Algorithm: "RS256", Key: &oldKey.PublicKey},
+		"rotated": {KeyID: "rotated", Algorithm: "RS256", Key: &rotatedKey.PublicKey},
+	}
+
+	tests := []struct {
+		name              string
+		refreshed         auth.KeySet
+		refreshErr        error
+		tokens            []string
+		expectedRefreshes int
+		expectedValid     []bool
+	}{
+		{
+			name:              "Success - rotated key is fetched once",
+			refreshed:         rotated,
+			tokens:            []string{signRS256(t, rotatedKey, "rotated", claims), signRS256(t, rotatedKey, "rotated", claims)},
+			expectedRefreshes: 1,
+			expectedValid:     []bool{true, true},
+		},
+		{
+			name:              "Success - known kid does not refresh",
+			refreshed:         rotated,
+			tokens:            []string{signRS256(t, oldKey, "old", claims)},
+			expectedRefreshes: 0,
+			expectedValid:     []bool{true},
+		},
+		{
+			name:              "Error - unknown kids are rate limited",
+			refreshed:         keys,
+			tokens:            []string{signRS256(t, rotatedKey, "missing1", claims), signRS256(t, rotatedKey, "missing2", claims)},
+			expectedRefreshes: 1,
+			expectedValid:     []bool{false, false},
+		},
+		{
+			name:              "Error - failed refresh keeps current keys",
+			refreshErr:        errors.New("jwks unavailable"),
+			tokens:            []string{signRS256(t, rotatedKey, "rotated", claims), signRS256(t, oldKey, "old", claims)},
+			expectedRefreshes: 1,
+			expectedValid:     []bool{false, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refreshes := 0
+			authenticator, err := auth.NewJWTAuthenticator(keys, auth.JWTOptions{
+				Audience: "pr-service",
+				RefreshKeys: func(ctx context.Context) (auth.KeySet, error) {
+					refreshes++
+					return test.refreshed, test.refreshErr
+				},
+				MinRefreshInterval: time.Hour,
+			})
+			assert.NoError(t, err)
+
+			for i, token := range test.tokens {
+				_, err := authenticator.Authenticate(context.Background(), token)
+				if test.expectedValid[i] {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, model.ErrUnauthenticated)
+				}
+			}
+			assert.Equal(t, test.expectedRefreshes, refreshes)
+		})
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	tests := []struct {
+		name          string
+		document      string
+		expectedKids  []string
+		expectedError string
+	}{
+		{
+			name:         "Success - unusable keys are skipped",
+			document:     `{"keys":[{"kty":"OKP","kid":"ed","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},{"kty":"EC","kid":"p384","crv":"P-384"},{"kty":"RSA","kid":"rsa","n":"sXch","e":"AQAB"}]}`,
+			expectedKids: []string{"rsa"},
+		},
+		{
+			name:          "Error - no usable keys",
+			document:      `{"keys":[{"kty":"OKP","kid":"ed","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`,
+			expectedError: "jwks has no usable signing keys",
+		},
+		{
+			name:          "Error - invalid json",
+			document:      `{"keys":`,
+			expectedError: "decode jwks: unexpected end of JSON input",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys, err := auth.ParseJWKS([]byte(test.document))
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			kids := make([]string, 0, len(keys))
+			for kid := range keys {
+				kids = append(kids, kid)
+			}
+			assert.ElementsMatch(t, test.expectedKids, kids)
+		})
+	}
+}
+
+func TestNewJWTAuthenticator_RequiresAudience(t *testing.T) {
+	keys := auth.KeySet{"k": auth.JSONWebKey{KeyID: "k"}}
+
+	_, err := auth.NewJWTAuthenticator(keys, auth.JWTOptions{})
+
+	assert.EqualError(t, err, "jwt audience is required")
+}
+
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	ecPublic, err := ecKey.PublicKey.Bytes()
+	assert.NoError(t, err)
+
+	document := map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa-key",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec-key",
+				"alg": "ES256",
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(ecPublic[1:33]),
+				"y":   base64.RawURLEncoding.EncodeToString(ecPublic[33:]),
+			},
+			{
+				"kty": "RSA",
+				"kid": "enc-key",
+				"use": "enc",
+			},
+			{
+				"kty": "OKP",
+				"kid": "ed-key",
+				"crv": "Ed25519",
+				"x":   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+			},
+		},
+	}
+
+	data, err := json.Marshal(document)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+
+	signingInput := encodeSegment(t, map[string]any{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assert.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+
+	signingInput := encodeSegment(t, map[string]any{"alg": "ES256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	assert.NoError(t, err)
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeSegment(t *testing.T, value any) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	assert.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type TokenRepository struct {
+	mock.Mock
+}
+
+func (m *TokenRepository) CreateToken(ctx context.Context, token *model.APIToken, tokenHash string) (*model.APIToken, error) {
+	args := m.Called(ctx, token, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIToken), args.Error(1)
+}
+
+func (m *TokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*model.APIToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIToken), args.Error(1)
+}
+
+func (m *TokenRepository) ListTokens(ctx context.Context) ([]model.APIToken, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.APIToken), args.Error(1)
+}
+
+func (m *TokenRepository) RevokeToken(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
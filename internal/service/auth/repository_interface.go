@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type TokenRepository interface {
+	CreateToken(ctx context.Context, token *model.APIToken, tokenHash string) (*model.APIToken, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*model.APIToken, error)
+	ListTokens(ctx context.Context) ([]model.APIToken, error)
+	RevokeToken(ctx context.Context, id int64) error
+}
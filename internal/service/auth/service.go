@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
+)
+
+const (
+	tokenPrefix  = "prs_"
+	tokenBytes   = 32
+	adminSubject = "admin"
+)
+
+type AuthService struct {
+	tokenRepository TokenRepository
+	adminToken      string
+}
+
+func NewAuthService(tokenRepository TokenRepository, adminToken string) *AuthService {
+	return &AuthService{
+		tokenRepository: tokenRepository,
+		adminToken:      adminToken,
+	}
+}
+
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*model.Principal, error) {
+	if token == "" {
+		return nil, model.ErrUnauthenticated
+	}
+	if s.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) == 1 {
+		return &model.Principal{Subject: adminSubject, Role: model.RoleAdmin}, nil
+	}
+
+	stored, err := s.tokenRepository.GetTokenByHash(ctx, HashToken(token))
+	if errors.Is(err, model.ErrTokenNotFound) {
+		return nil, model.ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+	if stored.RevokedAt != nil || (stored.ExpiresAt != nil && !time.Now().Before(*stored.ExpiresAt)) {
+		return nil, model.ErrUnauthenticated
+	}
+
+	return &model.Principal{
+		Subject:  tokenSubject(stored),
+		UserID:   stored.UserID,
+		Role:     stored.Role,
+		TokenID:  stored.ID,
+		TenantID: stored.TenantID,
+	}, nil
+}
+
+func (s *AuthService) IssueToken(ctx context.Context, token *model.APIToken) (*model.IssuedToken, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	token.Name = strings.TrimSpace(token.Name)
+	if !token.Role.IsValid() {
+		return nil, model.ErrInvalidRole
+	}
+	if token.Name == "" || (token.Role != model.RoleAdmin && token.UserID == "") {
+		return nil, model.ErrInvalidTokenRequest
+	}
+
+	secret, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate token: %w", err)
+	}
+
+	created, err := s.tokenRepository.CreateToken(ctx, token, HashToken(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.IssuedToken{Token: created, Secret: secret}, nil
+}
+
+func (s *AuthService) ListTokens(ctx context.Context) ([]model.APIToken, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.tokenRepository.ListTokens(ctx)
+}
+
+func (s *AuthService) RevokeToken(ctx context.Context, id int64) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	return s.tokenRepository.RevokeToken(ctx, id)
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func tokenSubject(token *model.APIToken) string {
+	if token.UserID != "" {
+		return token.UserID
+	}
+	return "token:" + token.Name
+}
+
+func requireAdmin(ctx context.Context) error {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return model.ErrUnauthenticated
+	}
+	if p.Role != model.RoleAdmin {
+		return model.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
+	"github.com/avito/internship/pr-service/internal/service/auth"
+	"github.com/avito/internship/pr-service/internal/service/auth/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name              string
+		token             string
+		setupMocks        func(*mocks.TokenRepository)
+		expectedPrincipal *model.Principal
+		expectedError     string
+	}{
+		{
+			name:              "Success - bootstrap admin token",
+			token:             "bootstrap-secret",
+			setupMocks:        func(tokenRepository *mocks.TokenRepository) {},
+			expectedPrincipal: &model.Principal{Subject: "admin", Role: model.RoleAdmin},
+		},
+		{
+			name:  "Success - member token",
+			token: "prs_member",
+			setupMocks: func(tokenRepository *mocks.TokenRepository) {
+				tokenRepository.On("GetTokenByHash", ctx, auth.HashToken("prs_member")).
+					Return(&model.APIToken{ID: 7, Name: "ci", UserID: "user1", Role: model.RoleMember, TenantID: "payments", ExpiresAt: &future}, nil).Once()
+			},
+			expectedPrincipal: &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleMember, TokenID: 7, TenantID: "payments"},
+		},
+		{
+			name:  "Success - service token without user",
+			token: "prs_service",
+			setupMocks: func(tokenRepository *mocks.TokenRepository) {
+				tokenRepository.On("GetTokenByHash", ctx, auth.HashToken("prs_service")).
+					Return(&model.APIToken{ID: 8, Name: "deploy-bot", Role: model.RoleAdmin}, nil).Once()
+			},
+			expectedPrincipal: &model.Principal{Subject: "token:deploy-bot", Role: model.RoleAdmin, TokenID: 8},
+		},
+		{
+			name:          "Error - empty token",
+			setupMocks:    func(tokenRepository *mocks.TokenRepository) {},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+		{
+			name:  "Error - unknown token",
+			token: "prs_unknown",
+			setupMocks: func(tokenRepository *mocks.TokenRepository) {
+				tokenRepository.On("GetTokenByHash", ctx, auth.HashToken("prs_unknown")).Return(nil, model.ErrTokenNotFound).Once()
+			},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+		{
+			name:  "Error - revoked token",
+			token: "prs_revoked",
+			setupMocks: func(tokenRepository *mocks.TokenRepository) {
+				tokenRepository.On("GetTokenByHash", ctx, auth.HashToken("prs_revoked")).
+					Return(&model.APIToken{ID: 9, UserID: "user1", Role: model.RoleMember, RevokedAt: &past}, nil).Once()
+			},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+		{
+			name:  "Error - expired token",
+			token: "prs_expired",
+			setupMocks: func(tokenRepository *mocks.TokenRepository) {
+				tokenRepository.On("GetTokenByHash", ctx, auth.HashToken("prs_expired")).
+					Return(&model.APIToken{ID: 10, UserID: "user1", Role: model.RoleMember, ExpiresAt: &past}, nil).Once()
+			},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+		{
+			name:  "Error on repository",
+			token: "prs_member",
+			setupMocks: func(tokenRepository *mocks.TokenRepository) {
+				tokenRepository.On("GetTokenByHash", ctx, auth.HashToken("prs_member")).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "get token: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokenRepository := new(mocks.TokenRepository)
+			test.setupMocks(tokenRepository)
+
+			s := auth.NewAuthService(tokenRepository, "bootstrap-secret")
+			p, err := s.Authenticate(ctx, test.token)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, p)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedPrincipal, p)
+			}
+			tokenRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_IssueToken(t *testing.T) {
+	adminCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "admin", Role: model.RoleAdmin})
+	memberCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleMember})
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		token         *model.APIToken
+		setupMocks    func(*mocks.TokenRepository, *string)
+		expectedError string
+	}{
+		{
+			name:  "Success - member token stored hashed",
+			ctx:   adminCtx,
+			token: &model.APIToken{Name: " laptop ", UserID: "user1", Role: model.RoleMember},
+			setupMocks: func(tokenRepository *mocks.TokenRepository, hash *string) {
+				tokenRepository.On("CreateToken", adminCtx, &model.APIToken{Name: "laptop", UserID: "user1", Role: model.RoleMember}, mock.AnythingOfType("string")).
+					Run(func(args mock.Arguments) { *hash = args.Get(2).(string) }).
+					Return(&model.APIToken{ID: 1, Name: "laptop", UserID: "user1", Role: model.RoleMember}, nil).Once()
+			},
+		},
+		{
+			name:          "Error - caller is not admin",
+			ctx:           memberCtx,
+			token:         &model.APIToken{Name: "laptop", UserID: "user1", Role: model.RoleMember},
+			setupMocks:    func(tokenRepository *mocks.TokenRepository, hash *string) {},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name:          "Error - no principal",
+			ctx:           context.Background(),
+			token:         &model.APIToken{Name: "laptop", UserID: "user1", Role: model.RoleMember},
+			setupMocks:    func(tokenRepository *mocks.TokenRepository, hash *string) {},
+			expectedError: model.ErrUnauthenticated.Error(),
+		},
+		{
+			name:          "Error - unknown role",
+			ctx:           adminCtx,
+			token:         &model.APIToken{Name: "laptop", UserID: "user1", Role: "owner"},
+			setupMocks:    func(tokenRepository *mocks.TokenRepository, hash *string) {},
+			expectedError: model.ErrInvalidRole.Error(),
+		},
+		{
+			name:          "Error - member token without user",
+			ctx:           adminCtx,
+			token:         &model.APIToken{Name: "laptop", Role: model.RoleMember},
+			setupMocks:    func(tokenRepository *mocks.TokenRepository, hash *string) {},
+			expectedError: model.ErrInvalidTokenRequest.Error(),
+		},
+		{
+			name:  "Error - user not found",
+			ctx:   adminCtx,
+			token: &model.APIToken{Name: "laptop", UserID: "ghost", Role: model.RoleMember},
+			setupMocks: func(tokenRepository *mocks.TokenRepository, hash *string) {
+				tokenRepository.On("CreateToken", adminCtx, mock.Anything, mock.Anything).Return(nil, model.ErrUserNotFound).Once()
+			},
+			expectedError: model.ErrUserNotFound.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokenRepository := new(mocks.TokenRepository)
+			var storedHash string
+			test.setupMocks(tokenRepository, &storedHash)
+
+			s := auth.NewAuthService(tokenRepository, "")
+			issued, err := s.IssueToken(test.ctx, test.token)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, issued)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(issued.Secret, "prs_"))
+				assert.Equal(t, auth.HashToken(issued.Secret), storedHash)
+				assert.NotContains(t, storedHash, issued.Secret)
+				assert.Equal(t, int64(1), issued.Token.ID)
+			}
+			tokenRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_RevokeToken(t *testing.T) {
+	adminCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "admin", Role: model.RoleAdmin})
+	memberCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "user1", UserID: "user1", Role: model.RoleMember})
+
+	t.Run("Success", func(t *testing.T) {
+		tokenRepository := new(mocks.TokenRepository)
+		tokenRepository.On("RevokeToken", adminCtx, int64(3)).Return(nil).Once()
+
+		s := auth.NewAuthService(tokenRepository, "")
+		assert.NoError(t, s.RevokeToken(adminCtx, 3))
+		tokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error - caller is not admin", func(t *testing.T) {
+		tokenRepository := new(mocks.TokenRepository)
+
+		s := auth.NewAuthService(tokenRepository, "")
+		assert.EqualError(t, s.RevokeToken(memberCtx, 3), model.ErrForbidden.Error())
+		tokenRepository.AssertExpectations(t)
+	})
+}
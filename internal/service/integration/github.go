@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+const githubPullRequestEvent = "pull_request"
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		ID       int64       `json:"id"`
+		Number   int         `json:"number"`
+		Title    string      `json:"title"`
+		Draft    bool        `json:"draft"`
+		Merged   bool        `json:"merged"`
+		User     githubUser  `json:"user"`
+		MergedBy *githubUser `json:"merged_by"`
+	} `json:"pull_request"`
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+func verifyGitHubSignature(secret string, body []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+	expected, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(expected)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), got)
+}
+
+func parseGitHubEvent(body []byte) (*model.ExternalPullRequestEvent, error) {
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, model.ErrBadWebhookPayload
+	}
+	pr := payload.PullRequest
+	if pr.ID == 0 || pr.User.Login == "" {
+		return nil, model.ErrBadWebhookPayload
+	}
+
+	event := &model.ExternalPullRequestEvent{
+		Provider:      model.ProviderGitHub,
+		Action:        model.ExternalAction(payload.Action),
+		PullRequestID: "gh-" + strconv.FormatInt(pr.ID, 10),
+		Title:         pr.Title,
+		AuthorLogin:   pr.User.Login,
+		IsDraft:       pr.Draft,
+		Payload:       body,
+	}
+	if payload.Action == "closed" && pr.Merged {
+		event.Action = model.ExternalActionMerged
+		if pr.MergedBy != nil {
+			event.MergedByLogin = pr.MergedBy.Login
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"strconv"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+const gitlabMergeRequestKind = "merge_request"
+
+type gitlabMergeRequestPayload struct {
+	ObjectKind       string     `json:"object_kind"`
+	User             gitlabUser `json:"user"`
+	ObjectAttributes struct {
+		ID     int64  `json:"id"`
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+type gitlabUser struct {
+	Username string `json:"username"`
+}
+
+var gitlabActions = map[string]model.ExternalAction{
+	"open":   model.ExternalActionOpened,
+	"merge":  model.ExternalActionMerged,
+	"close":  model.ExternalActionClosed,
+	"reopen": model.ExternalActionReopened,
+}
+
+func verifyGitLabToken(expected, token string) bool {
+	if expected == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
+
+func parseGitLabEvent(body []byte) (*model.ExternalPullRequestEvent, error) {
+	var payload gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, model.ErrBadWebhookPayload
+	}
+	if payload.ObjectKind != gitlabMergeRequestKind {
+		return nil, nil
+	}
+	attrs := payload.ObjectAttributes
+	if attrs.ID == 0 || payload.User.Username == "" {
+		return nil, model.ErrBadWebhookPayload
+	}
+
+	action, ok := gitlabActions[attrs.Action]
+	if !ok {
+		action = model.ExternalAction(attrs.Action)
+		if draft := payload.Changes.Draft; attrs.Action == "update" && draft != nil && draft.Previous && !draft.Current {
+			action = model.ExternalActionReadyForReview
+		}
+	}
+
+	event := &model.ExternalPullRequestEvent{
+		Provider:      model.ProviderGitLab,
+		Action:        action,
+		PullRequestID: "gl-" + strconv.FormatInt(attrs.ID, 10),
+		Title:         attrs.Title,
+		AuthorLogin:   payload.User.Username,
+		IsDraft:       attrs.Draft,
+		Payload:       body,
+	}
+	if action == model.ExternalActionMerged {
+		event.MergedByLogin = payload.User.Username
+	}
+	return event, nil
+}
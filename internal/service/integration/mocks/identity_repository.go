@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type IdentityRepository struct {
+	mock.Mock
+}
+
+func (m *IdentityRepository) SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error) {
+	args := m.Called(ctx, identity)
+	var saved *model.ExternalIdentity
+	if args.Get(0) != nil {
+		saved = args.Get(0).(*model.ExternalIdentity)
+	}
+	return saved, args.Error(1)
+}
+
+func (m *IdentityRepository) GetIdentity(ctx context.Context, provider model.ExternalProvider, login string) (*model.ExternalIdentity, error) {
+	args := m.Called(ctx, provider, login)
+	var identity *model.ExternalIdentity
+	if args.Get(0) != nil {
+		identity = args.Get(0).(*model.ExternalIdentity)
+	}
+	return identity, args.Error(1)
+}
+
+func (m *IdentityRepository) ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error) {
+	args := m.Called(ctx, provider)
+	var identities []model.ExternalIdentity
+	if args.Get(0) != nil {
+		identities = args.Get(0).([]model.ExternalIdentity)
+	}
+	return identities, args.Error(1)
+}
+
+func (m *IdentityRepository) DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error {
+	args := m.Called(ctx, provider, login)
+	return args.Error(0)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type PullRequestService struct {
+	mock.Mock
+}
+
+func (m *PullRequestService) Create(ctx context.Context, prID, prName, authorID string, isDraft bool) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID, prName, authorID, isDraft)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) Merge(ctx context.Context, prID string, opts model.MergeOptions) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID, opts)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) Close(ctx context.Context, prID string) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) Reopen(ctx context.Context, prID string) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	return pullRequest(args)
+}
+
+func (m *PullRequestService) MarkReady(ctx context.Context, prID string) (*model.PullRequest, error) {
+	args := m.Called(ctx, prID)
+	return pullRequest(args)
+}
+
+func pullRequest(args mock.Arguments) (*model.PullRequest, error) {
+	var pr *model.PullRequest
+	if args.Get(0) != nil {
+		pr = args.Get(0).(*model.PullRequest)
+	}
+	return pr, args.Error(1)
+}
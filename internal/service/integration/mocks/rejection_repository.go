@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type RejectionRepository struct {
+	mock.Mock
+}
+
+func (m *RejectionRepository) SaveRejection(ctx context.Context, rejection *model.IntegrationRejection) (*model.IntegrationRejection, error) {
+	args := m.Called(ctx, rejection)
+	var saved *model.IntegrationRejection
+	if args.Get(0) != nil {
+		saved = args.Get(0).(*model.IntegrationRejection)
+	}
+	return saved, args.Error(1)
+}
+
+func (m *RejectionRepository) ListRejections(ctx context.Context, provider model.ExternalProvider, limit int) ([]model.IntegrationRejection, error) {
+	args := m.Called(ctx, provider, limit)
+	var rejections []model.IntegrationRejection
+	if args.Get(0) != nil {
+		rejections = args.Get(0).([]model.IntegrationRejection)
+	}
+	return rejections, args.Error(1)
+}
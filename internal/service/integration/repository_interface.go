@@ -0,0 +1,27 @@
+package integration
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type IdentityRepository interface {
+	SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error)
+	GetIdentity(ctx context.Context, provider model.ExternalProvider, login string) (*model.ExternalIdentity, error)
+	ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error)
+	DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error
+}
+
+type RejectionRepository interface {
+	SaveRejection(ctx context.Context, rejection *model.IntegrationRejection) (*model.IntegrationRejection, error)
+	ListRejections(ctx context.Context, provider model.ExternalProvider, limit int) ([]model.IntegrationRejection, error)
+}
+
+type PullRequestService interface {
+	Create(ctx context.Context, prID, prName, authorID string, isDraft bool) (*model.PullRequest, error)
+	Merge(ctx context.Context, prID string, opts model.MergeOptions) (*model.PullRequest, error)
+	Close(ctx context.Context, prID string) (*model.PullRequest, error)
+	Reopen(ctx context.Context, prID string) (*model.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (*model.PullRequest, error)
+}
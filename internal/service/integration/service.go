@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/avito/internship/pr-service/internal/actor"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/tenant"
+)
+
+const rejectionsLimit = 100
+
+type Secrets struct {
+	GitHubWebhookSecrets map[string]string
+	GitLabWebhookTokens  map[string]string
+}
+
+type IntegrationService struct {
+	identityRepository  IdentityRepository
+	rejectionRepository RejectionRepository
+	pullRequestService  PullRequestService
+	secrets             Secrets
+}
+
+func NewIntegrationService(identityRepository IdentityRepository, rejectionRepository RejectionRepository, pullRequestService PullRequestService, secrets Secrets) *IntegrationService {
+	return &IntegrationService{
+		identityRepository:  identityRepository,
+		rejectionRepository: rejectionRepository,
+		pullRequestService:  pullRequestService,
+		secrets:             secrets,
+	}
+}
+
+func (s *IntegrationService) SaveIdentity(ctx context.Context, identity *model.ExternalIdentity) (*model.ExternalIdentity, error) {
+	if !identity.Provider.IsValid() {
+		return nil, model.ErrInvalidProvider
+	}
+	identity.ExternalLogin = normalizeLogin(identity.ExternalLogin)
+	if identity.ExternalLogin == "" || identity.UserID == "" {
+		return nil, model.ErrInvalidIdentity
+	}
+	return s.identityRepository.SaveIdentity(ctx, identity)
+}
+
+func (s *IntegrationService) ListIdentities(ctx context.Context, provider model.ExternalProvider) ([]model.ExternalIdentity, error) {
+	if provider != "" && !provider.IsValid() {
+		return nil, model.ErrInvalidProvider
+	}
+	return s.identityRepository.ListIdentities(ctx, provider)
+}
+
+func (s *IntegrationService) DeleteIdentity(ctx context.Context, provider model.ExternalProvider, login string) error {
+	if !provider.IsValid() {
+		return model.ErrInvalidProvider
+	}
+	return s.identityRepository.DeleteIdentity(ctx, provider, normalizeLogin(login))
+}
+
+func (s *IntegrationService) ListRejections(ctx context.Context, provider model.ExternalProvider) ([]model.IntegrationRejection, error) {
+	if provider != "" && !provider.IsValid() {
+		return nil, model.ErrInvalidProvider
+	}
+	return s.rejectionRepository.ListRejections(ctx, provider, rejectionsLimit)
+}
+
+func (s *IntegrationService) HandleGitHubWebhook(ctx context.Context, eventName, signature string, body []byte) (*model.IntegrationResult, error) {
+	if !verifyGitHubSignature(s.secrets.GitHubWebhookSecrets[tenant.FromContext(ctx)], body, signature) {
+		return nil, model.ErrInvalidSignature
+	}
+	if eventName != githubPullRequestEvent {
+		return &model.IntegrationResult{Status: model.IntegrationIgnored}, nil
+	}
+
+	event, err := parseGitHubEvent(body)
+	if err != nil {
+		return nil, err
+	}
+	return s.applyPullRequestEvent(ctx, event)
+}
+
+func (s *IntegrationService) HandleGitLabWebhook(ctx context.Context, token string, body []byte) (*model.IntegrationResult, error) {
+	if !verifyGitLabToken(s.secrets.GitLabWebhookTokens[tenant.FromContext(ctx)], token) {
+		return nil, model.ErrInvalidSignature
+	}
+
+	event, err := parseGitLabEvent(body)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return &model.IntegrationResult{Status: model.IntegrationIgnored}, nil
+	}
+	return s.applyPullRequestEvent(ctx, event)
+}
+
+func (s *IntegrationService) applyPullRequestEvent(ctx context.Context, event *model.ExternalPullRequestEvent) (*model.IntegrationResult, error) {
+	ctx = actor.WithActor(ctx, string(event.Provider))
+	result := &model.IntegrationResult{Status: model.IntegrationProcessed, Action: event.Action}
+
+	var err error
+	switch event.Action {
+	case model.ExternalActionOpened:
+		var authorID string
+		authorID, err = s.resolveUser(ctx, event.Provider, event.AuthorLogin)
+		if errors.Is(err, model.ErrIdentityNotFound) {
+			return s.reject(ctx, event, event.AuthorLogin, fmt.Sprintf("author %q is not mapped to a user", event.AuthorLogin))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("map author: %w", err)
+		}
+		result.PullRequest, err = s.pullRequestService.Create(ctx, event.PullRequestID, event.Title, authorID, event.IsDraft)
+		if errors.Is(err, model.ErrPullRequestExists) {
+			return &model.IntegrationResult{Status: model.IntegrationIgnored, Action: event.Action}, nil
+		}
+	case model.ExternalActionReadyForReview:
+		result.PullRequest, err = s.pullRequestService.MarkReady(ctx, event.PullRequestID)
+	case model.ExternalActionMerged:
+		opts := model.MergeOptions{Override: true}
+		if event.MergedByLogin != "" {
+			mergedBy, err := s.resolveUser(ctx, event.Provider, event.MergedByLogin)
+			if err != nil && !errors.Is(err, model.ErrIdentityNotFound) {
+				return nil, fmt.Errorf("map merger: %w", err)
+			}
+			opts.MergedBy = mergedBy
+		}
+		result.PullRequest, err = s.pullRequestService.Merge(ctx, event.PullRequestID, opts)
+	case model.ExternalActionClosed:
+		result.PullRequest, err = s.pullRequestService.Close(ctx, event.PullRequestID)
+	case model.ExternalActionReopened:
+		result.PullRequest, err = s.pullRequestService.Reopen(ctx, event.PullRequestID)
+	default:
+		return &model.IntegrationResult{Status: model.IntegrationIgnored, Action: event.Action}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("apply %s %s event: %w", event.Provider, event.Action, err)
+	}
+
+	return result, nil
+}
+
+func (s *IntegrationService) reject(ctx context.Context, event *model.ExternalPullRequestEvent, login, reason string) (*model.IntegrationResult, error) {
+	rejection, err := s.rejectionRepository.SaveRejection(ctx, &model.IntegrationRejection{
+		Provider:      event.Provider,
+		Action:        event.Action,
+		PullRequestID: event.PullRequestID,
+		ExternalLogin: login,
+		Reason:        reason,
+		Payload:       event.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("save rejection: %w", err)
+	}
+
+	return &model.IntegrationResult{
+		Status:    model.IntegrationRejected,
+		Action:    event.Action,
+		Rejection: rejection,
+	}, nil
+}
+
+func (s *IntegrationService) resolveUser(ctx context.Context, provider model.ExternalProvider, login string) (string, error) {
+	identity, err := s.identityRepository.GetIdentity(ctx, provider, normalizeLogin(login))
+	if err != nil {
+		return "", err
+	}
+	return identity.UserID, nil
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
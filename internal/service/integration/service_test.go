@@ -0,0 +1,458 @@
+package integration_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/avito/internship/pr-service/internal/actor"
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/integration"
+	"github.com/avito/internship/pr-service/internal/service/integration/mocks"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	githubSecret = "It's a Secret to Everybody"
+	gitlabToken  = "gitlab-token"
+)
+
+func readFixture(t *testing.T, path string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", path))
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", path, err)
+	}
+	return body
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestIntegrationService_HandleGitHubWebhook(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "github")
+	alice := &model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalLogin: "alice-dev", UserID: "u1"}
+	bob := &model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalLogin: "bob-lead", UserID: "u2"}
+	pr := &model.PullRequest{PullRequestID: "gh-1874502231"}
+
+	tests := []struct {
+		name           string
+		eventName      string
+		fixture        string
+		signature      string
+		setupMocks     func(*mocks.IdentityRepository, *mocks.RejectionRepository, *mocks.PullRequestService)
+		expectedStatus model.IntegrationStatus
+		expectedAction model.ExternalAction
+		expectedError  string
+	}{
+		{
+			name:      "Success - opened creates PR for mapped author",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_opened.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "alice-dev").Return(alice, nil).Once()
+				pullRequestService.On("Create", ctx, "gh-1874502231", "Add search endpoint", "u1", false).Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionOpened,
+		},
+		{
+			name:      "Success - opened draft creates draft PR",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_opened_draft.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "alice-dev").Return(alice, nil).Once()
+				pullRequestService.On("Create", ctx, "gh-1874502231", "Add search endpoint", "u1", true).Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionOpened,
+		},
+		{
+			name:      "Success - redelivered opened is ignored",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_opened.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "alice-dev").Return(alice, nil).Once()
+				pullRequestService.On("Create", ctx, "gh-1874502231", "Add search endpoint", "u1", false).Return(nil, model.ErrPullRequestExists).Once()
+			},
+			expectedStatus: model.IntegrationIgnored,
+			expectedAction: model.ExternalActionOpened,
+		},
+		{
+			name:      "Success - ready for review marks PR ready",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_ready_for_review.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				pullRequestService.On("MarkReady", ctx, "gh-1874502231").Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionReadyForReview,
+		},
+		{
+			name:      "Success - closed and merged merges PR with override",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_closed_merged.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "bob-lead").Return(bob, nil).Once()
+				pullRequestService.On("Merge", ctx, "gh-1874502231", model.MergeOptions{MergedBy: "u2", Override: true}).Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionMerged,
+		},
+		{
+			name:      "Success - merge by unmapped user keeps merged_by empty",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_closed_merged.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "bob-lead").Return(nil, model.ErrIdentityNotFound).Once()
+				pullRequestService.On("Merge", ctx, "gh-1874502231", model.MergeOptions{Override: true}).Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionMerged,
+		},
+		{
+			name:      "Success - closed without merge closes PR",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_closed.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				pullRequestService.On("Close", ctx, "gh-1874502231").Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionClosed,
+		},
+		{
+			name:      "Success - reopened reopens PR",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_reopened.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				pullRequestService.On("Reopen", ctx, "gh-1874502231").Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionReopened,
+		},
+		{
+			name:      "Success - unsupported action is ignored",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_labeled.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+			},
+			expectedStatus: model.IntegrationIgnored,
+			expectedAction: "labeled",
+		},
+		{
+			name:      "Success - ping is ignored",
+			eventName: "ping",
+			fixture:   "github/ping.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+			},
+			expectedStatus: model.IntegrationIgnored,
+		},
+		{
+			name:      "Error invalid signature",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_opened.json",
+			signature: "sha256=0000",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+			},
+			expectedError: "invalid webhook signature",
+		},
+		{
+			name:      "Success - unmapped author is rejected",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_opened.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "alice-dev").Return(nil, model.ErrIdentityNotFound).Once()
+				rejectionRepository.On("SaveRejection", ctx, mock.MatchedBy(func(rejection *model.IntegrationRejection) bool {
+					return rejection.Provider == model.ProviderGitHub &&
+						rejection.PullRequestID == "gh-1874502231" &&
+						rejection.ExternalLogin == "Alice-Dev" &&
+						rejection.Reason == `author "Alice-Dev" is not mapped to a user` &&
+						len(rejection.Payload) > 0
+				})).Return(&model.IntegrationRejection{ID: 1}, nil).Once()
+			},
+			expectedStatus: model.IntegrationRejected,
+			expectedAction: model.ExternalActionOpened,
+		},
+		{
+			name:      "Error on lookup author",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_opened.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "alice-dev").Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "map author: db error",
+		},
+		{
+			name:      "Error on merge",
+			eventName: "pull_request",
+			fixture:   "github/pull_request_closed_merged.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitHub, "bob-lead").Return(bob, nil).Once()
+				pullRequestService.On("Merge", ctx, "gh-1874502231", model.MergeOptions{MergedBy: "u2", Override: true}).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "apply github merged event: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identityRepository := new(mocks.IdentityRepository)
+			rejectionRepository := new(mocks.RejectionRepository)
+			pullRequestService := new(mocks.PullRequestService)
+			test.setupMocks(identityRepository, rejectionRepository, pullRequestService)
+
+			body := readFixture(t, test.fixture)
+			signature := test.signature
+			if signature == "" {
+				signature = sign(githubSecret, body)
+			}
+
+			s := integration.NewIntegrationService(identityRepository, rejectionRepository, pullRequestService, integration.Secrets{GitHubWebhookSecrets: map[string]string{tenant.Default: githubSecret}})
+			result, err := s.HandleGitHubWebhook(context.Background(), test.eventName, signature, body)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedStatus, result.Status)
+				assert.Equal(t, test.expectedAction, result.Action)
+			}
+
+			identityRepository.AssertExpectations(t)
+			rejectionRepository.AssertExpectations(t)
+			pullRequestService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIntegrationService_HandleGitHubWebhook_NoSecret(t *testing.T) {
+	body := readFixture(t, "github/pull_request_opened.json")
+
+	s := integration.NewIntegrationService(nil, nil, nil, integration.Secrets{})
+	_, err := s.HandleGitHubWebhook(context.Background(), "pull_request", sign("", body), body)
+
+	assert.ErrorIs(t, err, model.ErrInvalidSignature)
+}
+
+func TestIntegrationService_HandleGitHubWebhook_TenantSecret(t *testing.T) {
+	body := readFixture(t, "github/pull_request_opened.json")
+	ctx := tenant.WithTenant(context.Background(), "payments")
+
+	s := integration.NewIntegrationService(nil, nil, nil, integration.Secrets{
+		GitHubWebhookSecrets: map[string]string{tenant.Default: githubSecret, "payments": "payments-secret"},
+	})
+	_, err := s.HandleGitHubWebhook(ctx, "pull_request", sign(githubSecret, body), body)
+
+	assert.ErrorIs(t, err, model.ErrInvalidSignature)
+}
+
+func TestIntegrationService_HandleGitLabWebhook(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "gitlab")
+	carol := &model.ExternalIdentity{Provider: model.ProviderGitLab, ExternalLogin: "carol", UserID: "u3"}
+	dan := &model.ExternalIdentity{Provider: model.ProviderGitLab, ExternalLogin: "dan.lead", UserID: "u4"}
+	pr := &model.PullRequest{PullRequestID: "gl-99001"}
+
+	tests := []struct {
+		name           string
+		fixture        string
+		token          string
+		setupMocks     func(*mocks.IdentityRepository, *mocks.RejectionRepository, *mocks.PullRequestService)
+		expectedStatus model.IntegrationStatus
+		expectedAction model.ExternalAction
+		expectedError  string
+	}{
+		{
+			name:    "Success - open creates PR for mapped author",
+			fixture: "gitlab/merge_request_open.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitLab, "carol").Return(carol, nil).Once()
+				pullRequestService.On("Create", ctx, "gl-99001", "Cache team settings", "u3", false).Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionOpened,
+		},
+		{
+			name:    "Success - unmapped author is rejected and stored",
+			fixture: "gitlab/merge_request_open.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitLab, "carol").Return(nil, model.ErrIdentityNotFound).Once()
+				rejectionRepository.On("SaveRejection", ctx, mock.MatchedBy(func(rejection *model.IntegrationRejection) bool {
+					return rejection.Provider == model.ProviderGitLab &&
+						rejection.Action == model.ExternalActionOpened &&
+						rejection.PullRequestID == "gl-99001" &&
+						rejection.ExternalLogin == "carol" &&
+						rejection.Reason == `author "carol" is not mapped to a user` &&
+						len(rejection.Payload) > 0
+				})).Return(&model.IntegrationRejection{ID: 7}, nil).Once()
+			},
+			expectedStatus: model.IntegrationRejected,
+			expectedAction: model.ExternalActionOpened,
+		},
+		{
+			name:    "Success - draft removed marks PR ready",
+			fixture: "gitlab/merge_request_ready.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				pullRequestService.On("MarkReady", ctx, "gl-99001").Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionReadyForReview,
+		},
+		{
+			name:    "Success - merge merges PR on behalf of mapped user",
+			fixture: "gitlab/merge_request_merge.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitLab, "dan.lead").Return(dan, nil).Once()
+				pullRequestService.On("Merge", ctx, "gl-99001", model.MergeOptions{MergedBy: "u4", Override: true}).Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionMerged,
+		},
+		{
+			name:    "Success - close closes PR",
+			fixture: "gitlab/merge_request_close.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				pullRequestService.On("Close", ctx, "gl-99001").Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionClosed,
+		},
+		{
+			name:    "Success - reopen reopens PR",
+			fixture: "gitlab/merge_request_reopen.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				pullRequestService.On("Reopen", ctx, "gl-99001").Return(pr, nil).Once()
+			},
+			expectedStatus: model.IntegrationProcessed,
+			expectedAction: model.ExternalActionReopened,
+		},
+		{
+			name:    "Success - unsupported action is ignored",
+			fixture: "gitlab/merge_request_approved.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+			},
+			expectedStatus: model.IntegrationIgnored,
+			expectedAction: "approved",
+		},
+		{
+			name:    "Success - non merge request event is ignored",
+			fixture: "gitlab/push.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+			},
+			expectedStatus: model.IntegrationIgnored,
+		},
+		{
+			name:    "Error invalid token",
+			fixture: "gitlab/merge_request_open.json",
+			token:   "wrong",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+			},
+			expectedError: "invalid webhook signature",
+		},
+		{
+			name:    "Error on save rejection",
+			fixture: "gitlab/merge_request_open.json",
+			setupMocks: func(identityRepository *mocks.IdentityRepository, rejectionRepository *mocks.RejectionRepository, pullRequestService *mocks.PullRequestService) {
+				identityRepository.On("GetIdentity", ctx, model.ProviderGitLab, "carol").Return(nil, model.ErrIdentityNotFound).Once()
+				rejectionRepository.On("SaveRejection", ctx, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "save rejection: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identityRepository := new(mocks.IdentityRepository)
+			rejectionRepository := new(mocks.RejectionRepository)
+			pullRequestService := new(mocks.PullRequestService)
+			test.setupMocks(identityRepository, rejectionRepository, pullRequestService)
+
+			token := test.token
+			if token == "" {
+				token = gitlabToken
+			}
+
+			s := integration.NewIntegrationService(identityRepository, rejectionRepository, pullRequestService, integration.Secrets{GitLabWebhookTokens: map[string]string{tenant.Default: gitlabToken}})
+			result, err := s.HandleGitLabWebhook(context.Background(), token, readFixture(t, test.fixture))
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedStatus, result.Status)
+				assert.Equal(t, test.expectedAction, result.Action)
+			}
+
+			identityRepository.AssertExpectations(t)
+			rejectionRepository.AssertExpectations(t)
+			pullRequestService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIntegrationService_SaveIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		identity      *model.ExternalIdentity
+		setupMocks    func(*mocks.IdentityRepository)
+		expectedError string
+	}{
+		{
+			name:     "Success - login is normalized",
+			identity: &model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalLogin: " Alice-Dev ", UserID: "u1"},
+			setupMocks: func(identityRepository *mocks.IdentityRepository) {
+				expected := &model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalLogin: "alice-dev", UserID: "u1"}
+				identityRepository.On("SaveIdentity", ctx, expected).Return(expected, nil).Once()
+			},
+		},
+		{
+			name:          "Error unknown provider",
+			identity:      &model.ExternalIdentity{Provider: "bitbucket", ExternalLogin: "alice", UserID: "u1"},
+			setupMocks:    func(identityRepository *mocks.IdentityRepository) {},
+			expectedError: "unknown provider",
+		},
+		{
+			name:          "Error empty login",
+			identity:      &model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalLogin: "  ", UserID: "u1"},
+			setupMocks:    func(identityRepository *mocks.IdentityRepository) {},
+			expectedError: "login and user_id are required",
+		},
+		{
+			name:     "Error user not found",
+			identity: &model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalLogin: "alice", UserID: "ghost"},
+			setupMocks: func(identityRepository *mocks.IdentityRepository) {
+				identityRepository.On("SaveIdentity", ctx, &model.ExternalIdentity{Provider: model.ProviderGitHub, ExternalLogin: "alice", UserID: "ghost"}).Return(nil, model.ErrUserNotFound).Once()
+			},
+			expectedError: "user not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identityRepository := new(mocks.IdentityRepository)
+			test.setupMocks(identityRepository)
+
+			s := integration.NewIntegrationService(identityRepository, nil, nil, integration.Secrets{})
+			_, err := s.SaveIdentity(ctx, test.identity)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			identityRepository.AssertExpectations(t)
+		})
+	}
+}
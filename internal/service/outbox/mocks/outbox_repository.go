@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type OutboxRepository struct {
+	mock.Mock
+}
+
+func (m *OutboxRepository) Add(ctx context.Context, eventType model.EventType, payload []byte) error {
+	args := m.Called(ctx, eventType, payload)
+	return args.Error(0)
+}
+
+func (m *OutboxRepository) LockPending(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	args := m.Called(ctx, limit)
+	var events []model.OutboxEvent
+	if args.Get(0) != nil {
+		events = args.Get(0).([]model.OutboxEvent)
+	}
+	return events, args.Error(1)
+}
+
+func (m *OutboxRepository) MarkDelivered(ctx context.Context, ids []int64) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *OutboxRepository) MarkFailed(ctx context.Context, id int64, deliveredSinks []string, lastError string, nextAttemptAt *time.Time) error {
+	args := m.Called(ctx, id, deliveredSinks, lastError, nextAttemptAt)
+	return args.Error(0)
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type Sink struct {
+	mock.Mock
+	SinkName string
+}
+
+func (m *Sink) Name() string {
+	return m.SinkName
+}
+
+func (m *Sink) Handle(ctx context.Context, event model.OutboxEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type eventPayload struct {
+	Type         model.EventType      `json:"type"`
+	OccurredAt   time.Time            `json:"occurred_at"`
+	PullRequest  *pullRequestPayload  `json:"pull_request,omitempty"`
+	Reviewers    []string             `json:"reviewers,omitempty"`
+	Reassignment *reassignmentPayload `json:"reassignment,omitempty"`
+}
+
+type pullRequestPayload struct {
+	PullRequestID     string                  `json:"pull_request_id"`
+	PullRequestName   string                  `json:"pull_request_name"`
+	AuthorID          string                  `json:"author_id"`
+	Status            model.PullRequestStatus `json:"status"`
+	AssignedReviewers []string                `json:"assigned_reviewers"`
+	CreatedAt         time.Time               `json:"created_at"`
+	MergedAt          *time.Time              `json:"merged_at,omitempty"`
+}
+
+type reassignmentPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+func encodeEvent(event model.Event) ([]byte, error) {
+	payload := eventPayload{
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Reviewers:  event.Reviewers,
+	}
+	if pr := event.PullRequest; pr != nil {
+		payload.PullRequest = &pullRequestPayload{
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			Status:            pr.Status,
+			AssignedReviewers: pr.AssignedReviewers,
+			CreatedAt:         pr.CreatedAt,
+			MergedAt:          pr.MergedAt,
+		}
+	}
+	if r := event.Reassignment; r != nil {
+		payload.Reassignment = &reassignmentPayload{
+			PullRequestID: r.PullRequestID,
+			OldReviewerID: r.OldReviewerID,
+			NewReviewerID: r.NewReviewerID,
+		}
+	}
+	return json.Marshal(payload)
+}
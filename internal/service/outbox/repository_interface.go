@@ -0,0 +1,24 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type OutboxRepository interface {
+	Add(ctx context.Context, eventType model.EventType, payload []byte) error
+	LockPending(ctx context.Context, limit int) ([]model.OutboxEvent, error)
+	MarkDelivered(ctx context.Context, ids []int64) error
+	MarkFailed(ctx context.Context, id int64, deliveredSinks []string, lastError string, nextAttemptAt *time.Time) error
+}
+
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Sink interface {
+	Name() string
+	Handle(ctx context.Context, event model.OutboxEvent) error
+}
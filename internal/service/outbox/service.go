@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+type OutboxService struct {
+	outboxRepository OutboxRepository
+	txManager        TxManager
+	sinks            []Sink
+	policy           RetryPolicy
+}
+
+func NewOutboxService(outboxRepository OutboxRepository, txManager TxManager, sinks []Sink, policy RetryPolicy) *OutboxService {
+	return &OutboxService{
+		outboxRepository: outboxRepository,
+		txManager:        txManager,
+		sinks:            sinks,
+		policy:           policy,
+	}
+}
+
+func (s *OutboxService) Publish(ctx context.Context, event model.Event) error {
+	payload, err := encodeEvent(event)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	if err := s.outboxRepository.Add(ctx, event.Type, payload); err != nil {
+		return fmt.Errorf("add outbox event: %w", err)
+	}
+	return nil
+}
+
+func (s *OutboxService) Relay(ctx context.Context, limit int) (int, error) {
+	delivered := 0
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		events, err := s.outboxRepository.LockPending(ctx, limit)
+		if err != nil {
+			return fmt.Errorf("lock pending events: %w", err)
+		}
+
+		ids := make([]int64, 0, len(events))
+		for _, event := range events {
+			if deliveredSinks, sinkErr := s.dispatch(ctx, event); sinkErr != nil {
+				nextAttemptAt := s.nextAttemptAt(event.Attempts + 1)
+				if err := s.outboxRepository.MarkFailed(ctx, event.ID, deliveredSinks, sinkErr.Error(), nextAttemptAt); err != nil {
+					return fmt.Errorf("mark event %d failed: %w", event.ID, err)
+				}
+				continue
+			}
+			ids = append(ids, event.ID)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := s.outboxRepository.MarkDelivered(ctx, ids); err != nil {
+			return fmt.Errorf("mark events delivered: %w", err)
+		}
+		delivered = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return delivered, nil
+}
+
+func (s *OutboxService) dispatch(ctx context.Context, event model.OutboxEvent) ([]string, error) {
+	delivered := append([]string{}, event.DeliveredSinks...)
+	var errs []error
+	for _, sink := range s.sinks {
+		if slices.Contains(event.DeliveredSinks, sink.Name()) {
+			continue
+		}
+		if err := sink.Handle(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s sink: %w", sink.Name(), err))
+			continue
+		}
+		delivered = append(delivered, sink.Name())
+	}
+	return delivered, errors.Join(errs...)
+}
+
+func (s *OutboxService) nextAttemptAt(attempts int) *time.Time {
+	if attempts >= s.policy.MaxAttempts {
+		return nil
+	}
+
+	backoff := s.policy.BackoffBase << (attempts - 1)
+	if backoff <= 0 || backoff > s.policy.BackoffMax {
+		backoff = s.policy.BackoffMax
+	}
+	next := time.Now().Add(backoff)
+	return &next
+}
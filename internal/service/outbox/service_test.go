@@ -0,0 +1,182 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/outbox"
+	"github.com/avito/internship/pr-service/internal/service/outbox/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxService_Publish(t *testing.T) {
+	ctx := context.Background()
+	occurredAt := time.Date(2025, time.November, 19, 10, 0, 0, 0, time.UTC)
+	event := model.Event{
+		Type:       model.EventReviewerReassigned,
+		OccurredAt: occurredAt,
+		Reassignment: &model.ReviewReassignment{
+			PullRequestID: "pr1",
+			OldReviewerID: "user2",
+			NewReviewerID: "user3",
+		},
+	}
+
+	outboxRepository := new(mocks.OutboxRepository)
+	outboxRepository.On("Add", ctx, model.EventReviewerReassigned, mock.Anything).Run(func(args mock.Arguments) {
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal(args.Get(2).([]byte), &payload))
+		assert.Equal(t, "pull_request.reassigned", payload["type"])
+		assert.Equal(t, "2025-11-19T10:00:00Z", payload["occurred_at"])
+		assert.Equal(t, map[string]any{
+			"pull_request_id": "pr1",
+			"old_reviewer_id": "user2",
+			"new_reviewer_id": "user3",
+		}, payload["reassignment"])
+		assert.NotContains(t, payload, "pull_request")
+	}).Return(nil).Once()
+
+	s := outbox.NewOutboxService(outboxRepository, nil, nil, outbox.RetryPolicy{})
+	assert.NoError(t, s.Publish(ctx, event))
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestOutboxService_Relay(t *testing.T) {
+	ctx := context.Background()
+	first := model.OutboxEvent{ID: 1, TenantID: "default", Type: model.EventPullRequestCreated, Payload: []byte(`{}`)}
+	second := model.OutboxEvent{ID: 2, Type: model.EventPullRequestMerged, Payload: []byte(`{}`)}
+	retried := model.OutboxEvent{ID: 3, Type: model.EventPullRequestMerged, Payload: []byte(`{}`), Attempts: 1, DeliveredSinks: []string{"log"}}
+	exhausted := model.OutboxEvent{ID: 4, Type: model.EventPullRequestMerged, Payload: []byte(`{}`), Attempts: 2}
+	policy := outbox.RetryPolicy{MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour}
+
+	tests := []struct {
+		name              string
+		setupMocks        func(*mocks.OutboxRepository, *mocks.Sink, *mocks.Sink)
+		expectedDelivered int
+		expectedError     string
+	}{
+		{
+			name: "Success - all sinks handle every event",
+			setupMocks: func(outboxRepository *mocks.OutboxRepository, logSink *mocks.Sink, webhookSink *mocks.Sink) {
+				outboxRepository.On("LockPending", ctx, 10).Return([]model.OutboxEvent{first, second}, nil).Once()
+				logSink.On("Handle", ctx, first).Return(nil).Once()
+				logSink.On("Handle", ctx, second).Return(nil).Once()
+				webhookSink.On("Handle", ctx, first).Return(nil).Once()
+				webhookSink.On("Handle", ctx, second).Return(nil).Once()
+				outboxRepository.On("MarkDelivered", ctx, []int64{1, 2}).Return(nil).Once()
+			},
+			expectedDelivered: 2,
+		},
+		{
+			name: "Success - failed event stays pending",
+			setupMocks: func(outboxRepository *mocks.OutboxRepository, logSink *mocks.Sink, webhookSink *mocks.Sink) {
+				outboxRepository.On("LockPending", ctx, 10).Return([]model.OutboxEvent{first, second}, nil).Once()
+				logSink.On("Handle", ctx, first).Return(nil).Once()
+				logSink.On("Handle", ctx, second).Return(nil).Once()
+				webhookSink.On("Handle", ctx, first).Return(errors.New("db error")).Once()
+				webhookSink.On("Handle", ctx, second).Return(nil).Once()
+				outboxRepository.On("MarkFailed", ctx, int64(1), []string{"log"}, "webhook sink: db error", mock.AnythingOfType("*time.Time")).Run(func(args mock.Arguments) {
+					next := args.Get(4).(*time.Time)
+					assert.NotNil(t, next)
+					assert.WithinDuration(t, time.Now().Add(time.Minute), *next, 5*time.Second)
+				}).Return(nil).Once()
+				outboxRepository.On("MarkDelivered", ctx, []int64{2}).Return(nil).Once()
+			},
+			expectedDelivered: 1,
+		},
+		{
+			name: "Success - delivered sinks are not repeated",
+			setupMocks: func(outboxRepository *mocks.OutboxRepository, logSink *mocks.Sink, webhookSink *mocks.Sink) {
+				outboxRepository.On("LockPending", ctx, 10).Return([]model.OutboxEvent{retried}, nil).Once()
+				webhookSink.On("Handle", ctx, retried).Return(nil).Once()
+				outboxRepository.On("MarkDelivered", ctx, []int64{3}).Return(nil).Once()
+			},
+			expectedDelivered: 1,
+		},
+		{
+			name: "Success - event dead after max attempts",
+			setupMocks: func(outboxRepository *mocks.OutboxRepository, logSink *mocks.Sink, webhookSink *mocks.Sink) {
+				outboxRepository.On("LockPending", ctx, 10).Return([]model.OutboxEvent{exhausted}, nil).Once()
+				logSink.On("Handle", ctx, exhausted).Return(errors.New("disk full")).Once()
+				webhookSink.On("Handle", ctx, exhausted).Return(nil).Once()
+				outboxRepository.On("MarkFailed", ctx, int64(4), []string{"webhook"}, "log sink: disk full", (*time.Time)(nil)).Return(nil).Once()
+			},
+			expectedDelivered: 0,
+		},
+		{
+			name: "Success - nothing pending",
+			setupMocks: func(outboxRepository *mocks.OutboxRepository, logSink *mocks.Sink, webhookSink *mocks.Sink) {
+				outboxRepository.On("LockPending", ctx, 10).Return([]model.OutboxEvent{}, nil).Once()
+			},
+			expectedDelivered: 0,
+		},
+		{
+			name: "Error on lock pending events",
+			setupMocks: func(outboxRepository *mocks.OutboxRepository, logSink *mocks.Sink, webhookSink *mocks.Sink) {
+				outboxRepository.On("LockPending", ctx, 10).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "lock pending events: db error",
+		},
+		{
+			name: "Error on mark delivered",
+			setupMocks: func(outboxRepository *mocks.OutboxRepository, logSink *mocks.Sink, webhookSink *mocks.Sink) {
+				outboxRepository.On("LockPending", ctx, 10).Return([]model.OutboxEvent{first}, nil).Once()
+				logSink.On("Handle", ctx, first).Return(nil).Once()
+				webhookSink.On("Handle", ctx, first).Return(nil).Once()
+				outboxRepository.On("MarkDelivered", ctx, []int64{1}).Return(errors.New("db error")).Once()
+			},
+			expectedError: "mark events delivered: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outboxRepository := new(mocks.OutboxRepository)
+			logSink := &mocks.Sink{SinkName: "log"}
+			webhookSink := &mocks.Sink{SinkName: "webhook"}
+			txManager := new(mocks.TxManager)
+			test.setupMocks(outboxRepository, logSink, webhookSink)
+
+			s := outbox.NewOutboxService(outboxRepository, txManager, []outbox.Sink{logSink, webhookSink}, policy)
+			delivered, err := s.Relay(ctx, 10)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedDelivered, delivered)
+			}
+			assert.Equal(t, 1, txManager.Calls)
+
+			outboxRepository.AssertExpectations(t)
+			logSink.AssertExpectations(t)
+			webhookSink.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFileSink_Handle(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	createdAt := time.Date(2025, time.November, 19, 10, 0, 0, 0, time.UTC)
+
+	sink := outbox.NewFileSink(path)
+	assert.NoError(t, sink.Handle(ctx, model.OutboxEvent{ID: 1, TenantID: "default", Type: model.EventPullRequestCreated, Payload: []byte(`{"a":1}`), CreatedAt: createdAt}))
+	assert.NoError(t, sink.Handle(ctx, model.OutboxEvent{ID: 2, TenantID: "acme", Type: model.EventPullRequestMerged, Payload: []byte(`{"b":2}`), CreatedAt: createdAt}))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	assert.Equal(t, []string{
+		`{"id":1,"tenant_id":"default","type":"pull_request.created","created_at":"2025-11-19T10:00:00Z","payload":{"a":1}}`,
+		`{"id":2,"tenant_id":"acme","type":"pull_request.merged","created_at":"2025-11-19T10:00:00Z","payload":{"b":2}}`,
+	}, lines)
+}
@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Handle(_ context.Context, event model.OutboxEvent) error {
+	log.Printf("event %d %s (tenant %s): %s", event.ID, event.Type, event.TenantID, event.Payload)
+	return nil
+}
+
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+type fileRecord struct {
+	ID        int64           `json:"id"`
+	TenantID  string          `json:"tenant_id"`
+	Type      model.EventType `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func (s *FileSink) Handle(_ context.Context, event model.OutboxEvent) error {
+	line, err := json.Marshal(fileRecord{
+		ID:        event.ID,
+		TenantID:  event.TenantID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Payload:   event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("encode record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", s.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write %s: %w", s.path, err)
+	}
+	return nil
+}
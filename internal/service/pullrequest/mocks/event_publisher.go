@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventPublisher struct {
+	mock.Mock
+}
+
+func (m *EventPublisher) Publish(ctx context.Context, event model.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
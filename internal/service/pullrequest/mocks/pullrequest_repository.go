@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/avito/internship/pr-service/internal/model"
 	"github.com/stretchr/testify/mock"
@@ -20,8 +21,8 @@ func (m *PullRequestRepository) Get(ctx context.Context, id string) (*model.Pull
 	return pullRequest, args.Error(1)
 }
 
-func (m *PullRequestRepository) Create(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
-	args := m.Called(ctx, pr)
+func (m *PullRequestRepository) Create(ctx context.Context, pr *model.PullRequest, dueAt *time.Time) (*model.PullRequest, error) {
+	args := m.Called(ctx, pr, dueAt)
 	var createdPullRequest *model.PullRequest
 	if args.Get(0) != nil {
 		createdPullRequest = args.Get(0).(*model.PullRequest)
@@ -29,17 +30,49 @@ func (m *PullRequestRepository) Create(ctx context.Context, pr *model.PullReques
 	return createdPullRequest, args.Error(1)
 }
 
-func (m *PullRequestRepository) Merge(ctx context.Context, id string) (*model.PullRequest, error) {
+func (m *PullRequestRepository) Merge(ctx context.Context, id string, opts model.MergeOptions) (*model.PullRequest, bool, error) {
+	args := m.Called(ctx, id, opts)
+	var pullRequest *model.PullRequest
+	if args.Get(0) != nil {
+		pullRequest = args.Get(0).(*model.PullRequest)
+	}
+	return pullRequest, args.Bool(1), args.Error(2)
+}
+
+func (m *PullRequestRepository) Close(ctx context.Context, id string) (*model.PullRequest, bool, error) {
 	args := m.Called(ctx, id)
 	var pullRequest *model.PullRequest
 	if args.Get(0) != nil {
 		pullRequest = args.Get(0).(*model.PullRequest)
 	}
-	return pullRequest, args.Error(1)
+	return pullRequest, args.Bool(1), args.Error(2)
+}
+
+func (m *PullRequestRepository) Reopen(ctx context.Context, id string) (*model.PullRequest, bool, error) {
+	args := m.Called(ctx, id)
+	var pullRequest *model.PullRequest
+	if args.Get(0) != nil {
+		pullRequest = args.Get(0).(*model.PullRequest)
+	}
+	return pullRequest, args.Bool(1), args.Error(2)
+}
+
+func (m *PullRequestRepository) MarkReady(ctx context.Context, id string, reviewerIDs []string, dueAt *time.Time) (*model.PullRequest, bool, error) {
+	args := m.Called(ctx, id, reviewerIDs, dueAt)
+	var pullRequest *model.PullRequest
+	if args.Get(0) != nil {
+		pullRequest = args.Get(0).(*model.PullRequest)
+	}
+	return pullRequest, args.Bool(1), args.Error(2)
 }
 
-func (m *PullRequestRepository) UpdateReviewer(ctx context.Context, pullRequestID, oldReviewerID, newReviewerID string) error {
-	args := m.Called(ctx, pullRequestID, oldReviewerID, newReviewerID)
+func (m *PullRequestRepository) UpdateReviewer(ctx context.Context, pullRequestID, oldReviewerID, newReviewerID string, reason model.AssignmentReason, dueAt *time.Time) error {
+	args := m.Called(ctx, pullRequestID, oldReviewerID, newReviewerID, reason, dueAt)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepository) SetReviewState(ctx context.Context, pullRequestID, reviewerID string, state model.ReviewState) error {
+	args := m.Called(ctx, pullRequestID, reviewerID, state)
 	return args.Error(0)
 }
 
@@ -51,3 +84,85 @@ func (m *PullRequestRepository) GetUsersPullRequests(ctx context.Context, userId
 	}
 	return pullRequests, args.Error(1)
 }
+
+func (m *PullRequestRepository) LockUnderstaffedPullRequests(ctx context.Context, limit int) ([]model.PullRequest, error) {
+	args := m.Called(ctx, limit)
+	var pullRequests []model.PullRequest
+	if args.Get(0) != nil {
+		pullRequests = args.Get(0).([]model.PullRequest)
+	}
+	return pullRequests, args.Error(1)
+}
+
+func (m *PullRequestRepository) DeferFill(ctx context.Context, pullRequestID string, retryAt time.Time) error {
+	args := m.Called(ctx, pullRequestID, retryAt)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepository) AddReviewers(ctx context.Context, pullRequestID string, reviewerIDs []string, dueAt *time.Time) ([]string, error) {
+	args := m.Called(ctx, pullRequestID, reviewerIDs, dueAt)
+	var added []string
+	if args.Get(0) != nil {
+		added = args.Get(0).([]string)
+	}
+	return added, args.Error(1)
+}
+
+func (m *PullRequestRepository) GetOpenReviewsByUsers(ctx context.Context, userIDs []string) ([]model.PullRequest, error) {
+	args := m.Called(ctx, userIDs)
+	var pullRequests []model.PullRequest
+	if args.Get(0) != nil {
+		pullRequests = args.Get(0).([]model.PullRequest)
+	}
+	return pullRequests, args.Error(1)
+}
+
+func (m *PullRequestRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, userIDs)
+	var counts map[string]int
+	if args.Get(0) != nil {
+		counts = args.Get(0).(map[string]int)
+	}
+	return counts, args.Error(1)
+}
+
+func (m *PullRequestRepository) ReplaceReviewers(ctx context.Context, reassignments []model.ReviewReassignment, reason model.AssignmentReason, dueAt *time.Time) error {
+	args := m.Called(ctx, reassignments, reason, dueAt)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepository) GetOverdueReviews(ctx context.Context, now time.Time) ([]model.OverdueReview, error) {
+	args := m.Called(ctx, now)
+	var reviews []model.OverdueReview
+	if args.Get(0) != nil {
+		reviews = args.Get(0).([]model.OverdueReview)
+	}
+	return reviews, args.Error(1)
+}
+
+func (m *PullRequestRepository) LockOverdueReviews(ctx context.Context, now time.Time, limit int) ([]model.OverdueReview, error) {
+	args := m.Called(ctx, now, limit)
+	var reviews []model.OverdueReview
+	if args.Get(0) != nil {
+		reviews = args.Get(0).([]model.OverdueReview)
+	}
+	return reviews, args.Error(1)
+}
+
+func (m *PullRequestRepository) RecordEscalation(ctx context.Context, escalation model.ReviewEscalation) error {
+	args := m.Called(ctx, escalation)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepository) DeferEscalation(ctx context.Context, pullRequestID, reviewerID string, retryAt time.Time) error {
+	args := m.Called(ctx, pullRequestID, reviewerID, retryAt)
+	return args.Error(0)
+}
+
+func (m *PullRequestRepository) GetReviewerHistory(ctx context.Context, pullRequestID string) ([]model.ReviewerAssignment, error) {
+	args := m.Called(ctx, pullRequestID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ReviewerAssignment), args.Error(1)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReviewerSelector struct {
+	mock.Mock
+}
+
+func (m *ReviewerSelector) Select(ctx context.Context, team *model.Team, candidates []string, count int) ([]string, error) {
+	args := m.Called(ctx, team, candidates, count)
+	var selected []string
+	if args.Get(0) != nil {
+		selected = args.Get(0).([]string)
+	}
+	return selected, args.Error(1)
+}
+
+func (m *ReviewerSelector) SelectWithLoads(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string {
+	args := m.Called(ctx, team, candidates, count, loads)
+	var selected []string
+	if args.Get(0) != nil {
+		selected = args.Get(0).([]string)
+	}
+	return selected
+}
@@ -2,16 +2,32 @@ package pullrequest
 
 import (
 	"context"
+	"time"
 
 	"github.com/avito/internship/pr-service/internal/model"
 )
 
 type PullRequestRepository interface {
 	Get(ctx context.Context, id string) (*model.PullRequest, error)
-	Create(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error)
-	Merge(ctx context.Context, id string) (*model.PullRequest, error)
-	UpdateReviewer(ctx context.Context, pullRequestID, oldReviewerID, newReviewerID string) error
+	Create(ctx context.Context, pr *model.PullRequest, dueAt *time.Time) (*model.PullRequest, error)
+	Merge(ctx context.Context, id string, opts model.MergeOptions) (*model.PullRequest, bool, error)
+	Close(ctx context.Context, id string) (*model.PullRequest, bool, error)
+	Reopen(ctx context.Context, id string) (*model.PullRequest, bool, error)
+	MarkReady(ctx context.Context, id string, reviewerIDs []string, dueAt *time.Time) (*model.PullRequest, bool, error)
+	UpdateReviewer(ctx context.Context, pullRequestID, oldReviewerID, newReviewerID string, reason model.AssignmentReason, dueAt *time.Time) error
+	SetReviewState(ctx context.Context, pullRequestID, reviewerID string, state model.ReviewState) error
 	GetUsersPullRequests(ctx context.Context, userId string) ([]model.PullRequest, error)
+	LockUnderstaffedPullRequests(ctx context.Context, limit int) ([]model.PullRequest, error)
+	DeferFill(ctx context.Context, pullRequestID string, retryAt time.Time) error
+	AddReviewers(ctx context.Context, pullRequestID string, reviewerIDs []string, dueAt *time.Time) ([]string, error)
+	GetOpenReviewsByUsers(ctx context.Context, userIDs []string) ([]model.PullRequest, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	ReplaceReviewers(ctx context.Context, reassignments []model.ReviewReassignment, reason model.AssignmentReason, dueAt *time.Time) error
+	GetOverdueReviews(ctx context.Context, now time.Time) ([]model.OverdueReview, error)
+	LockOverdueReviews(ctx context.Context, now time.Time, limit int) ([]model.OverdueReview, error)
+	RecordEscalation(ctx context.Context, escalation model.ReviewEscalation) error
+	DeferEscalation(ctx context.Context, pullRequestID, reviewerID string, retryAt time.Time) error
+	GetReviewerHistory(ctx context.Context, pullRequestID string) ([]model.ReviewerAssignment, error)
 }
 
 type UserService interface {
@@ -21,3 +37,21 @@ type UserService interface {
 type TeamService interface {
 	GetTeamByName(ctx context.Context, teamName string) (*model.Team, error)
 }
+
+type ReviewerSelector interface {
+	Select(ctx context.Context, team *model.Team, candidates []string, count int) ([]string, error)
+	SelectWithLoads(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string
+}
+
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	AfterCommit(ctx context.Context, fn func())
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, event model.AuditEvent) error
+}
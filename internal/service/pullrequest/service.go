@@ -2,58 +2,115 @@ package pullrequest
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/avito/internship/pr-service/internal/metrics"
 	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
+	"github.com/avito/internship/pr-service/internal/service/sla"
+)
+
+const (
+	escalationRetryDelay = 30 * time.Minute
+	fillRetryDelay       = 10 * time.Minute
 )
 
 type PullRequestService struct {
 	pullRequestRepository PullRequestRepository
 	userService           UserService
 	teamService           TeamService
+	reviewerSelector      ReviewerSelector
+	txManager             TxManager
+	eventPublisher        EventPublisher
+	auditRecorder         AuditRecorder
+	calendar              sla.Calendar
 }
 
-func NewPullRequestService(pullRequestRepository PullRequestRepository, userService UserService, teamService TeamService) *PullRequestService {
+func NewPullRequestService(pullRequestRepository PullRequestRepository, userService UserService, teamService TeamService, reviewerSelector ReviewerSelector, txManager TxManager, eventPublisher EventPublisher, auditRecorder AuditRecorder, calendar sla.Calendar) *PullRequestService {
 	return &PullRequestService{
 		pullRequestRepository: pullRequestRepository,
 		userService:           userService,
 		teamService:           teamService,
+		reviewerSelector:      reviewerSelector,
+		txManager:             txManager,
+		eventPublisher:        eventPublisher,
+		auditRecorder:         auditRecorder,
+		calendar:              calendar,
 	}
 }
 
-func (s *PullRequestService) Create(ctx context.Context, prID, prName, authorID string) (*model.PullRequest, error) {
+func (s *PullRequestService) Create(ctx context.Context, prID, prName, authorID string, isDraft bool) (*model.PullRequest, error) {
 	author, err := s.userService.GetUserByID(ctx, authorID)
 	if err != nil {
 		return nil, fmt.Errorf("get author: %w", err)
 	}
 
-	team, err := s.teamService.GetTeamByName(ctx, author.TeamName)
-	if err != nil {
-		return nil, fmt.Errorf("get team: %w", err)
+	status := model.PullRequestDraft
+	reviewers := []string{}
+	var dueAt *time.Time
+	if !isDraft {
+		var team *model.Team
+		status = model.PullRequestOpen
+		team, reviewers, err = s.selectAuthorTeamReviewers(ctx, author, "create")
+		if err != nil {
+			return nil, err
+		}
+		dueAt = s.reviewDueAt(team)
 	}
 
-	reviewers := s.selectReviewers(authorID, team.Users)
-
 	pr := &model.PullRequest{
 		PullRequestID:     prID,
 		PullRequestName:   prName,
 		AuthorID:          authorID,
-		Status:            model.PullRequestOpen,
+		Status:            status,
 		AssignedReviewers: reviewers,
 		CreatedAt:         time.Now(),
 	}
 
-	createdPR, err := s.pullRequestRepository.Create(ctx, pr)
+	var createdPR *model.PullRequest
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		createdPR, err = s.pullRequestRepository.Create(ctx, pr, dueAt)
+		if err != nil {
+			return fmt.Errorf("create PR: %w", err)
+		}
+
+		err = s.audit(ctx, model.AuditEvent{
+			Action:        model.AuditActionCreate,
+			TargetType:    model.AuditTargetPullRequest,
+			TargetID:      prID,
+			PullRequestID: prID,
+			UserID:        author.UserID,
+			TeamName:      author.TeamName,
+			After:         createdPR,
+		})
+		if err != nil {
+			return err
+		}
+
+		events := []model.Event{{Type: model.EventPullRequestCreated, PullRequest: createdPR}}
+		if len(createdPR.AssignedReviewers) > 0 {
+			events = append(events, model.Event{Type: model.EventReviewersAssigned, PullRequest: createdPR, Reviewers: createdPR.AssignedReviewers})
+		}
+		return s.publish(ctx, events...)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create PR: %w", err)
+		return nil, err
 	}
+	s.txManager.AfterCommit(ctx, metrics.PullRequestsCreated.Inc)
 
 	return createdPR, nil
 }
 
-func (s *PullRequestService) Merge(ctx context.Context, prID string) (*model.PullRequest, error) {
+func (s *PullRequestService) Merge(ctx context.Context, prID string, opts model.MergeOptions) (*model.PullRequest, error) {
+	opts, err := bindMerger(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	pr, err := s.pullRequestRepository.Get(ctx, prID)
 	if err != nil {
 		return nil, fmt.Errorf("get PR to merge: %w", err)
@@ -62,15 +119,233 @@ func (s *PullRequestService) Merge(ctx context.Context, prID string) (*model.Pul
 	if pr.Status == model.PullRequestMerged {
 		return pr, nil
 	}
+	if pr.Status == model.PullRequestClosed {
+		return nil, model.ErrPRClosed
+	}
+	if pr.Status == model.PullRequestDraft {
+		return nil, model.ErrPRDraft
+	}
 
-	mergedPR, err := s.pullRequestRepository.Merge(ctx, prID)
+	author, err := s.userService.GetUserByID(ctx, pr.AuthorID)
 	if err != nil {
-		return nil, fmt.Errorf("merge PR: %w", err)
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	if !opts.Override {
+		team, err := s.teamService.GetTeamByName(ctx, author.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("get team: %w", err)
+		}
+
+		if conditions := unmetMergeConditions(pr, team.Settings.MergePolicy, opts.MergedBy); len(conditions) > 0 {
+			return nil, model.NewMergeBlockedError(conditions)
+		}
+	}
+
+	var mergedPR *model.PullRequest
+	var merged bool
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		mergedPR, merged, err = s.pullRequestRepository.Merge(ctx, prID, opts)
+		if err != nil {
+			return fmt.Errorf("merge PR: %w", err)
+		}
+		if !merged {
+			return nil
+		}
+
+		err = s.audit(ctx, model.AuditEvent{
+			Action:        model.AuditActionMerge,
+			TargetType:    model.AuditTargetPullRequest,
+			TargetID:      prID,
+			PullRequestID: prID,
+			UserID:        author.UserID,
+			TeamName:      author.TeamName,
+			Before:        pr,
+			After:         mergedPR,
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, model.Event{Type: model.EventPullRequestMerged, PullRequest: mergedPR})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if merged {
+		s.txManager.AfterCommit(ctx, metrics.PullRequestsMerged.Inc)
 	}
 
 	return mergedPR, nil
 }
 
+func unmetMergeConditions(pr *model.PullRequest, policy model.MergePolicy, mergedBy string) []string {
+	var conditions []string
+
+	approvals := 0
+	var changesRequestedBy []string
+	for _, review := range pr.Reviews {
+		switch review.State {
+		case model.ReviewStateApproved:
+			approvals++
+		case model.ReviewStateChangesRequested:
+			changesRequestedBy = append(changesRequestedBy, review.ReviewerID)
+		}
+	}
+
+	if approvals < policy.MinApprovals {
+		conditions = append(conditions, fmt.Sprintf("%d of %d required approvals", approvals, policy.MinApprovals))
+	}
+	if policy.BlockOnChangesRequested && len(changesRequestedBy) > 0 {
+		conditions = append(conditions, fmt.Sprintf("changes requested by %s", strings.Join(changesRequestedBy, ", ")))
+	}
+	if policy.AuthorCannotMerge {
+		switch mergedBy {
+		case "":
+			conditions = append(conditions, "merged_by is required")
+		case pr.AuthorID:
+			conditions = append(conditions, "author cannot merge own pull request")
+		}
+	}
+
+	return conditions
+}
+
+func bindMerger(ctx context.Context, opts model.MergeOptions) (model.MergeOptions, error) {
+	caller, ok := principal.FromContext(ctx)
+	if !ok || caller.Role == model.RoleAdmin {
+		return opts, nil
+	}
+	if opts.Override || caller.UserID == "" || (opts.MergedBy != "" && opts.MergedBy != caller.UserID) {
+		return opts, model.ErrForbidden
+	}
+	opts.MergedBy = caller.UserID
+	return opts, nil
+}
+
+func (s *PullRequestService) Close(ctx context.Context, prID string) (*model.PullRequest, error) {
+	pr, err := s.pullRequestRepository.Get(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get PR to close: %w", err)
+	}
+
+	switch pr.Status {
+	case model.PullRequestClosed:
+		return pr, nil
+	case model.PullRequestMerged:
+		return nil, model.ErrPRMergedNoClose
+	}
+
+	author, err := s.userService.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	var closedPR *model.PullRequest
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var closed bool
+		closedPR, closed, err = s.pullRequestRepository.Close(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("close PR: %w", err)
+		}
+		if !closed {
+			return nil
+		}
+		return s.audit(ctx, statusAudit(model.AuditActionClose, author, pr, closedPR))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return closedPR, nil
+}
+
+func (s *PullRequestService) Reopen(ctx context.Context, prID string) (*model.PullRequest, error) {
+	pr, err := s.pullRequestRepository.Get(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get PR to reopen: %w", err)
+	}
+
+	switch pr.Status {
+	case model.PullRequestOpen, model.PullRequestDraft:
+		return pr, nil
+	case model.PullRequestMerged:
+		return nil, model.ErrPRMergedNoClose
+	}
+
+	author, err := s.userService.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	var reopenedPR *model.PullRequest
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var reopened bool
+		reopenedPR, reopened, err = s.pullRequestRepository.Reopen(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("reopen PR: %w", err)
+		}
+		if !reopened {
+			return nil
+		}
+		return s.audit(ctx, statusAudit(model.AuditActionReopen, author, pr, reopenedPR))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reopenedPR, nil
+}
+
+func (s *PullRequestService) MarkReady(ctx context.Context, prID string) (*model.PullRequest, error) {
+	pr, err := s.pullRequestRepository.Get(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get PR to mark ready: %w", err)
+	}
+
+	switch pr.Status {
+	case model.PullRequestOpen:
+		return pr, nil
+	case model.PullRequestMerged:
+		return nil, model.ErrPRMerged
+	case model.PullRequestClosed:
+		return nil, model.ErrPRClosed
+	}
+
+	author, err := s.userService.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	team, reviewers, err := s.selectAuthorTeamReviewers(ctx, author, "mark_ready")
+	if err != nil {
+		return nil, err
+	}
+
+	var readyPR *model.PullRequest
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var ready bool
+		readyPR, ready, err = s.pullRequestRepository.MarkReady(ctx, prID, reviewers, s.reviewDueAt(team))
+		if err != nil {
+			return fmt.Errorf("mark PR ready: %w", err)
+		}
+		if !ready {
+			return nil
+		}
+		if err := s.audit(ctx, statusAudit(model.AuditActionMarkReady, author, pr, readyPR)); err != nil {
+			return err
+		}
+		if len(reviewers) == 0 {
+			return nil
+		}
+		return s.publish(ctx, model.Event{Type: model.EventReviewersAssigned, PullRequest: readyPR, Reviewers: reviewers})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return readyPR, nil
+}
+
 func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID string) (*model.ReassignResponse, error) {
 	pr, err := s.pullRequestRepository.Get(ctx, prID)
 	if err != nil {
@@ -80,6 +355,12 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 	if pr.Status == model.PullRequestMerged {
 		return nil, model.ErrPRMerged
 	}
+	if pr.Status == model.PullRequestClosed {
+		return nil, model.ErrPRClosed
+	}
+	if pr.Status == model.PullRequestDraft {
+		return nil, model.ErrPRDraft
+	}
 
 	isAssigned := false
 	for _, r := range pr.AssignedReviewers {
@@ -102,20 +383,45 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 		return nil, fmt.Errorf("get team: %w", err)
 	}
 
-	newReviewerID, err := s.selectNewReviewer(pr.AuthorID, pr.AssignedReviewers, team.Users)
+	newReviewerID, err := s.selectNewReviewer(ctx, pr.AuthorID, pr.AssignedReviewers, team)
 	if err != nil {
+		if errors.Is(err, model.ErrNoReviewerCandidates) {
+			metrics.NoCandidateFailures.WithLabelValues("reassign").Inc()
+		}
 		return nil, err
 	}
 
-	err = s.pullRequestRepository.UpdateReviewer(ctx, prID, oldReviewerID, newReviewerID)
-	if err != nil {
-		return nil, fmt.Errorf("update reviewer: %w", err)
+	reassignment := model.ReviewReassignment{
+		PullRequestID: prID,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
 	}
 
-	updatedPR, err := s.pullRequestRepository.Get(ctx, prID)
+	var updatedPR *model.PullRequest
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.pullRequestRepository.UpdateReviewer(ctx, prID, oldReviewerID, newReviewerID, model.AssignmentReasonReassign, s.reviewDueAt(team))
+		if err != nil {
+			return fmt.Errorf("update reviewer: %w", err)
+		}
+
+		updatedPR, err = s.pullRequestRepository.Get(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("get updated PR: %w", err)
+		}
+
+		if err := s.audit(ctx, reassignmentAudits(team.TeamName, []model.ReviewReassignment{reassignment})...); err != nil {
+			return err
+		}
+		return s.publish(ctx, model.Event{
+			Type:         model.EventReviewerReassigned,
+			PullRequest:  updatedPR,
+			Reassignment: &reassignment,
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get updated PR: %w", err)
+		return nil, err
 	}
+	s.txManager.AfterCommit(ctx, metrics.ReviewersReassigned.WithLabelValues("reassign").Inc)
 
 	return &model.ReassignResponse{
 		PullRequest: updatedPR,
@@ -123,6 +429,219 @@ func (s *PullRequestService) Reassign(ctx context.Context, prID, oldReviewerID s
 	}, nil
 }
 
+func (s *PullRequestService) SubmitReview(ctx context.Context, prID, reviewerID string, state model.ReviewState) (*model.PullRequest, error) {
+	if !state.IsVerdict() {
+		return nil, model.ErrInvalidReviewState
+	}
+
+	pr, err := s.pullRequestRepository.Get(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get PR to review: %w", err)
+	}
+
+	switch pr.Status {
+	case model.PullRequestMerged:
+		return nil, model.ErrPRMergedNoReview
+	case model.PullRequestClosed:
+		return nil, model.ErrPRClosed
+	case model.PullRequestDraft:
+		return nil, model.ErrPRDraft
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, model.ErrReviewerNotAssigned
+	}
+
+	if err := s.pullRequestRepository.SetReviewState(ctx, prID, reviewerID, state); err != nil {
+		return nil, fmt.Errorf("set review state: %w", err)
+	}
+
+	reviewedPR, err := s.pullRequestRepository.Get(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewed PR: %w", err)
+	}
+
+	return reviewedPR, nil
+}
+
+func (s *PullRequestService) ReassignUserReviews(ctx context.Context, userID string) (*model.ReassignmentReport, error) {
+	user, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer: %w", err)
+	}
+
+	return s.ReassignReviews(ctx, user.TeamName, []string{userID})
+}
+
+func (s *PullRequestService) ReassignReviews(ctx context.Context, teamName string, userIDs []string) (*model.ReassignmentReport, error) {
+	team, err := s.teamService.GetTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get team: %w", err)
+	}
+
+	prs, err := s.pullRequestRepository.GetOpenReviewsByUsers(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get open reviews: %w", err)
+	}
+
+	removed := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		removed[userID] = true
+	}
+
+	pool := make([]string, 0, len(team.Users))
+	for _, user := range team.Users {
+		if user.IsActive && !removed[user.UserID] {
+			pool = append(pool, user.UserID)
+		}
+	}
+
+	loads := map[string]int{}
+	if len(prs) > 0 && len(pool) > 0 {
+		loads, err = s.pullRequestRepository.GetOpenReviewCounts(ctx, pool)
+		if err != nil {
+			return nil, fmt.Errorf("get open review counts: %w", err)
+		}
+	}
+
+	report := &model.ReassignmentReport{
+		Reassigned: []model.ReviewReassignment{},
+		Failed:     []model.ReassignmentFailure{},
+	}
+	for _, pr := range prs {
+		reviewers := append([]string(nil), pr.AssignedReviewers...)
+		for i, reviewerID := range reviewers {
+			if !removed[reviewerID] {
+				continue
+			}
+
+			candidates := make([]string, 0, len(pool))
+			for _, userID := range pool {
+				if userID != pr.AuthorID && !slices.Contains(reviewers, userID) {
+					candidates = append(candidates, userID)
+				}
+			}
+
+			selected := s.reviewerSelector.SelectWithLoads(ctx, team, candidates, 1, loads)
+			if len(selected) == 0 {
+				report.Failed = append(report.Failed, model.ReassignmentFailure{
+					PullRequestID: pr.PullRequestID,
+					ReviewerID:    reviewerID,
+					Reason:        model.ErrNoReviewerCandidates.Error(),
+				})
+				continue
+			}
+
+			reviewers[i] = selected[0]
+			loads[selected[0]]++
+			report.Reassigned = append(report.Reassigned, model.ReviewReassignment{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: reviewerID,
+				NewReviewerID: selected[0],
+			})
+		}
+	}
+
+	if len(report.Reassigned) > 0 {
+		err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.pullRequestRepository.ReplaceReviewers(ctx, report.Reassigned, model.AssignmentReasonDeactivation, s.reviewDueAt(team)); err != nil {
+				return fmt.Errorf("replace reviewers: %w", err)
+			}
+			if err := s.audit(ctx, reassignmentAudits(team.TeamName, report.Reassigned)...); err != nil {
+				return err
+			}
+			return s.publish(ctx, reassignmentEvents(report.Reassigned)...)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.txManager.AfterCommit(ctx, func() {
+		metrics.ReviewersReassigned.WithLabelValues("deactivation").Add(float64(len(report.Reassigned)))
+		metrics.NoCandidateFailures.WithLabelValues("deactivation").Add(float64(len(report.Failed)))
+	})
+
+	return report, nil
+}
+
+func (s *PullRequestService) FillMissingReviewers(ctx context.Context, limit int) error {
+	var errs []error
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		prs, err := s.pullRequestRepository.LockUnderstaffedPullRequests(ctx, limit)
+		if err != nil {
+			return fmt.Errorf("lock understaffed PRs: %w", err)
+		}
+
+		now := time.Now()
+		teams := make(map[string]*model.Team)
+		for _, pr := range prs {
+			err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				return s.fillPullRequestReviewers(ctx, pr.PullRequestID, teams)
+			})
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, model.ErrNoReviewerCandidates) {
+				errs = append(errs, fmt.Errorf("fill reviewers for PR %s: %w", pr.PullRequestID, err))
+			}
+			if err := s.pullRequestRepository.DeferFill(ctx, pr.PullRequestID, now.Add(fillRetryDelay)); err != nil {
+				return fmt.Errorf("defer fill of PR %s: %w", pr.PullRequestID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *PullRequestService) fillPullRequestReviewers(ctx context.Context, prID string, teams map[string]*model.Team) error {
+	pr, err := s.pullRequestRepository.Get(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("get PR: %w", err)
+	}
+	if pr.Status != model.PullRequestOpen {
+		return nil
+	}
+
+	author, err := s.userService.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return fmt.Errorf("get author: %w", err)
+	}
+
+	team, ok := teams[author.TeamName]
+	if !ok {
+		team, err = s.teamService.GetTeamByName(ctx, author.TeamName)
+		if err != nil {
+			return fmt.Errorf("get team: %w", err)
+		}
+		teams[author.TeamName] = team
+	}
+
+	missing := team.Settings.MaxReviewers - len(pr.AssignedReviewers)
+	if missing <= 0 {
+		return nil
+	}
+
+	reviewers, err := s.selectAdditionalReviewers(ctx, pr.AuthorID, pr.AssignedReviewers, team, missing)
+	if err != nil {
+		return err
+	}
+
+	added, err := s.pullRequestRepository.AddReviewers(ctx, pr.PullRequestID, reviewers, s.reviewDueAt(team))
+	if err != nil {
+		return fmt.Errorf("add reviewers: %w", err)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, added...)
+	return s.publish(ctx, model.Event{Type: model.EventReviewersAssigned, PullRequest: pr, Reviewers: added})
+}
+
 func (s *PullRequestService) GetPullRequestsForUserReview(ctx context.Context, userId string) ([]model.PullRequest, error) {
 	if _, err := s.userService.GetUserByID(ctx, userId); err != nil {
 		return nil, err
@@ -130,25 +649,250 @@ func (s *PullRequestService) GetPullRequestsForUserReview(ctx context.Context, u
 	return s.pullRequestRepository.GetUsersPullRequests(ctx, userId)
 }
 
-func (s *PullRequestService) selectReviewers(authorID string, users []model.User) []string {
+func (s *PullRequestService) GetPullRequest(ctx context.Context, prID string) (*model.PullRequest, error) {
+	return s.pullRequestRepository.Get(ctx, prID)
+}
+
+func (s *PullRequestService) GetHistory(ctx context.Context, prID string) (*model.PullRequestHistory, error) {
+	if _, err := s.pullRequestRepository.Get(ctx, prID); err != nil {
+		return nil, fmt.Errorf("get PR: %w", err)
+	}
+
+	assignments, err := s.pullRequestRepository.GetReviewerHistory(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer history: %w", err)
+	}
+
+	return &model.PullRequestHistory{PullRequestID: prID, Assignments: assignments}, nil
+}
+
+func (s *PullRequestService) GetOverdueReviews(ctx context.Context) ([]model.OverdueReview, error) {
+	reviews, err := s.pullRequestRepository.GetOverdueReviews(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("get overdue reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (s *PullRequestService) EscalateOverdueReviews(ctx context.Context, limit int) (*model.ReassignmentReport, error) {
+	report := &model.ReassignmentReport{
+		Reassigned: []model.ReviewReassignment{},
+		Failed:     []model.ReassignmentFailure{},
+	}
+	noCandidates := 0
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		reviews, err := s.pullRequestRepository.LockOverdueReviews(ctx, now, limit)
+		if err != nil {
+			return fmt.Errorf("lock overdue reviews: %w", err)
+		}
+
+		teams := make(map[string]*model.Team)
+		for _, review := range reviews {
+			var newReviewerID string
+			err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				var err error
+				newReviewerID, err = s.escalateReview(ctx, review, teams)
+				return err
+			})
+			if err != nil {
+				if errors.Is(err, model.ErrNoReviewerCandidates) {
+					noCandidates++
+				}
+				report.Failed = append(report.Failed, model.ReassignmentFailure{
+					PullRequestID: review.PullRequestID,
+					ReviewerID:    review.ReviewerID,
+					Reason:        err.Error(),
+				})
+				if err := s.pullRequestRepository.DeferEscalation(ctx, review.PullRequestID, review.ReviewerID, now.Add(escalationRetryDelay)); err != nil {
+					return fmt.Errorf("defer escalation of %s on PR %s: %w", review.ReviewerID, review.PullRequestID, err)
+				}
+				continue
+			}
+
+			report.Reassigned = append(report.Reassigned, model.ReviewReassignment{
+				PullRequestID: review.PullRequestID,
+				OldReviewerID: review.ReviewerID,
+				NewReviewerID: newReviewerID,
+			})
+		}
+		return s.publish(ctx, reassignmentEvents(report.Reassigned)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.txManager.AfterCommit(ctx, func() {
+		metrics.ReviewersReassigned.WithLabelValues("escalation").Add(float64(len(report.Reassigned)))
+		metrics.NoCandidateFailures.WithLabelValues("escalation").Add(float64(noCandidates))
+	})
+
+	return report, nil
+}
+
+func (s *PullRequestService) escalateReview(ctx context.Context, review model.OverdueReview, teams map[string]*model.Team) (string, error) {
+	pr, err := s.pullRequestRepository.Get(ctx, review.PullRequestID)
+	if err != nil {
+		return "", fmt.Errorf("get PR: %w", err)
+	}
+
+	reviewer, err := s.userService.GetUserByID(ctx, review.ReviewerID)
+	if err != nil {
+		return "", fmt.Errorf("get reviewer: %w", err)
+	}
+
+	team, ok := teams[reviewer.TeamName]
+	if !ok {
+		team, err = s.teamService.GetTeamByName(ctx, reviewer.TeamName)
+		if err != nil {
+			return "", fmt.Errorf("get team: %w", err)
+		}
+		teams[reviewer.TeamName] = team
+	}
+
+	newReviewerID, err := s.selectNewReviewer(ctx, pr.AuthorID, pr.AssignedReviewers, team)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.pullRequestRepository.UpdateReviewer(ctx, pr.PullRequestID, review.ReviewerID, newReviewerID, model.AssignmentReasonEscalation, s.reviewDueAt(team)); err != nil {
+		return "", fmt.Errorf("update reviewer: %w", err)
+	}
+
+	escalation := model.ReviewEscalation{
+		PullRequestID: pr.PullRequestID,
+		OldReviewerID: review.ReviewerID,
+		NewReviewerID: newReviewerID,
+		Reason:        fmt.Sprintf("review SLA exceeded: no verdict by %s", review.DueAt.Format(time.RFC3339)),
+	}
+	if err := s.pullRequestRepository.RecordEscalation(ctx, escalation); err != nil {
+		return "", fmt.Errorf("record escalation: %w", err)
+	}
+
+	reassignment := model.ReviewReassignment{
+		PullRequestID: pr.PullRequestID,
+		OldReviewerID: review.ReviewerID,
+		NewReviewerID: newReviewerID,
+	}
+	if err := s.audit(ctx, reassignmentAudits(team.TeamName, []model.ReviewReassignment{reassignment})...); err != nil {
+		return "", err
+	}
+
+	return newReviewerID, nil
+}
+
+func (s *PullRequestService) publish(ctx context.Context, events ...model.Event) error {
+	now := time.Now()
+	for _, event := range events {
+		if event.OccurredAt.IsZero() {
+			event.OccurredAt = now
+		}
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("publish %s event: %w", event.Type, err)
+		}
+	}
+	return nil
+}
+
+func (s *PullRequestService) audit(ctx context.Context, events ...model.AuditEvent) error {
+	for _, event := range events {
+		if err := s.auditRecorder.Record(ctx, event); err != nil {
+			return fmt.Errorf("record %s audit event: %w", event.Action, err)
+		}
+	}
+	return nil
+}
+
+func statusAudit(action model.AuditAction, author *model.User, before, after *model.PullRequest) model.AuditEvent {
+	return model.AuditEvent{
+		Action:        action,
+		TargetType:    model.AuditTargetPullRequest,
+		TargetID:      before.PullRequestID,
+		PullRequestID: before.PullRequestID,
+		UserID:        author.UserID,
+		TeamName:      author.TeamName,
+		Before:        before,
+		After:         after,
+	}
+}
+
+func reassignmentAudits(teamName string, reassignments []model.ReviewReassignment) []model.AuditEvent {
+	events := make([]model.AuditEvent, 0, len(reassignments))
+	for _, reassignment := range reassignments {
+		events = append(events, model.AuditEvent{
+			Action:        model.AuditActionReassign,
+			TargetType:    model.AuditTargetPullRequest,
+			TargetID:      reassignment.PullRequestID,
+			PullRequestID: reassignment.PullRequestID,
+			UserID:        reassignment.OldReviewerID,
+			TeamName:      teamName,
+			Before:        map[string]string{"reviewer_id": reassignment.OldReviewerID},
+			After:         map[string]string{"reviewer_id": reassignment.NewReviewerID},
+		})
+	}
+	return events
+}
+
+func reassignmentEvents(reassignments []model.ReviewReassignment) []model.Event {
+	events := make([]model.Event, 0, len(reassignments))
+	for i := range reassignments {
+		events = append(events, model.Event{Type: model.EventReviewerReassigned, Reassignment: &reassignments[i]})
+	}
+	return events
+}
+
+func (s *PullRequestService) reviewDueAt(team *model.Team) *time.Time {
+	return s.calendar.DueAt(time.Now(), team.Settings.ReviewSLAHours)
+}
+
+func (s *PullRequestService) selectAuthorTeamReviewers(ctx context.Context, author *model.User, operation string) (*model.Team, []string, error) {
+	team, err := s.teamService.GetTeamByName(ctx, author.TeamName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get team: %w", err)
+	}
+
+	reviewers, err := s.selectReviewers(ctx, author.UserID, team)
+	if err != nil {
+		if errors.Is(err, model.ErrNotEnoughReviewers) {
+			metrics.NoCandidateFailures.WithLabelValues(operation).Inc()
+		}
+		return nil, nil, err
+	}
+	return team, reviewers, nil
+}
+
+func (s *PullRequestService) selectReviewers(ctx context.Context, authorID string, team *model.Team) ([]string, error) {
 	candidates := make([]string, 0)
-	for _, user := range users {
+	for _, user := range team.Users {
 		if user.IsActive && user.UserID != authorID {
 			candidates = append(candidates, user.UserID)
 		}
 	}
 
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	if len(candidates) < team.Settings.MinReviewers {
+		return nil, model.ErrNotEnoughReviewers
+	}
 
-	if len(candidates) > 2 {
-		return candidates[:2]
+	reviewers, err := s.reviewerSelector.Select(ctx, team, candidates, team.Settings.MaxReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("select reviewers: %w", err)
 	}
-	return candidates
+	return reviewers, nil
 }
 
-func (s *PullRequestService) selectNewReviewer(authorID string, currentReviewers []string, users []model.User) (string, error) {
+func (s *PullRequestService) selectNewReviewer(ctx context.Context, authorID string, currentReviewers []string, team *model.Team) (string, error) {
+	selected, err := s.selectAdditionalReviewers(ctx, authorID, currentReviewers, team, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(selected) == 0 {
+		return "", model.ErrNoReviewerCandidates
+	}
+
+	return selected[0], nil
+}
+
+func (s *PullRequestService) selectAdditionalReviewers(ctx context.Context, authorID string, currentReviewers []string, team *model.Team, count int) ([]string, error) {
 	candidates := make([]string, 0)
 	isReviewer := func(userID string) bool {
 		for _, r := range currentReviewers {
@@ -159,19 +903,20 @@ func (s *PullRequestService) selectNewReviewer(authorID string, currentReviewers
 		return false
 	}
 
-	for _, user := range users {
+	for _, user := range team.Users {
 		if user.IsActive && user.UserID != authorID && !isReviewer(user.UserID) {
 			candidates = append(candidates, user.UserID)
 		}
 	}
 
 	if len(candidates) == 0 {
-		return "", model.ErrNoReviewerCandidates
+		return nil, model.ErrNoReviewerCandidates
 	}
 
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	selected, err := s.reviewerSelector.Select(ctx, team, candidates, count)
+	if err != nil {
+		return nil, fmt.Errorf("select new reviewer: %w", err)
+	}
 
-	return candidates[0], nil
+	return selected, nil
 }
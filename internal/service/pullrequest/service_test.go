@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
 	"github.com/avito/internship/pr-service/internal/service/pullrequest"
 	"github.com/avito/internship/pr-service/internal/service/pullrequest/mocks"
+	"github.com/avito/internship/pr-service/internal/service/sla"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,25 +26,62 @@ func TestPullRequestService_Create(t *testing.T) {
 			{UserID: "user3", IsActive: true},
 			{UserID: "user4", IsActive: false},
 		},
+		Settings: model.TeamSettings{MaxReviewers: 2},
+	}
+	smallTeam := &model.Team{
+		TeamName: "team-a",
+		Users:    team.Users,
+		Settings: model.TeamSettings{MaxReviewers: 1},
+	}
+	strictTeam := &model.Team{
+		TeamName: "team-a",
+		Users:    team.Users,
+		Settings: model.TeamSettings{MinReviewers: 3, MaxReviewers: 3},
+	}
+	slaTeam := &model.Team{
+		TeamName: "team-a",
+		Users:    team.Users,
+		Settings: model.TeamSettings{MaxReviewers: 2, ReviewSLAHours: 4},
 	}
 
 	tests := []struct {
-		name          string
-		setupMocks    func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService)
-		prID          string
-		prName        string
-		authorID      string
-		expectedError string
+		name           string
+		setupMocks     func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService, *mocks.ReviewerSelector)
+		prID           string
+		prName         string
+		authorID       string
+		isDraft        bool
+		publishErr     error
+		expectedError  string
+		expectedEvents []model.EventType
 	}{
+		{
+			name:     "Success - draft skips reviewer selection",
+			prID:     "pr1",
+			prName:   "New Feature",
+			authorID: "author1",
+			isDraft:  true,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest"), (*time.Time)(nil)).Run(func(args mock.Arguments) {
+					pr := args.Get(1).(*model.PullRequest)
+					assert.Equal(t, model.PullRequestDraft, pr.Status)
+					assert.Empty(t, pr.AssignedReviewers)
+				}).Return(&model.PullRequest{}, nil).Once()
+			},
+			expectedError:  "",
+			expectedEvents: []model.EventType{model.EventPullRequestCreated},
+		},
 		{
 			name:     "Success",
 			prID:     "pr1",
 			prName:   "New Feature",
 			authorID: "author1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
 				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
 				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
-				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest")).Run(func(args mock.Arguments) {
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest"), (*time.Time)(nil)).Run(func(args mock.Arguments) {
 					pr := args.Get(1).(*model.PullRequest)
 					assert.Equal(t, "pr1", pr.PullRequestID)
 					assert.Equal(t, "author1", pr.AuthorID)
@@ -50,16 +89,90 @@ func TestPullRequestService_Create(t *testing.T) {
 					assert.Len(t, pr.AssignedReviewers, 2)
 					assert.NotContains(t, pr.AssignedReviewers, "author1")
 					assert.NotContains(t, pr.AssignedReviewers, "user4")
+				}).Return(&model.PullRequest{PullRequestID: "pr1", AssignedReviewers: []string{"user2", "user3"}}, nil).Once()
+			},
+			expectedError:  "",
+			expectedEvents: []model.EventType{model.EventPullRequestCreated, model.EventReviewersAssigned},
+		},
+		{
+			name:     "Success - review due time from team SLA",
+			prID:     "pr1",
+			prName:   "New Feature",
+			authorID: "author1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(slaTeam, nil).Once()
+				reviewerSelector.On("Select", ctx, slaTeam, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest"), mock.AnythingOfType("*time.Time")).Run(func(args mock.Arguments) {
+					dueAt := args.Get(2).(*time.Time)
+					assert.NotNil(t, dueAt)
+					assert.True(t, dueAt.After(time.Now()))
 				}).Return(&model.PullRequest{}, nil).Once()
 			},
-			expectedError: "",
+			expectedError:  "",
+			expectedEvents: []model.EventType{model.EventPullRequestCreated},
+		},
+		{
+			name:     "Error on write outbox event",
+			prID:     "pr1",
+			prName:   "New Feature",
+			authorID: "author1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest"), (*time.Time)(nil)).Return(&model.PullRequest{PullRequestID: "pr1"}, nil).Once()
+			},
+			publishErr:     errors.New("db error"),
+			expectedError:  "publish pull_request.created event: db error",
+			expectedEvents: []model.EventType{model.EventPullRequestCreated},
+		},
+		{
+			name:     "Error on select reviewers",
+			prID:     "pr1",
+			prName:   "New Feature",
+			authorID: "author1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "select reviewers: db error",
+		},
+		{
+			name:     "Success - team max reviewers honored",
+			prID:     "pr1",
+			prName:   "New Feature",
+			authorID: "author1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(smallTeam, nil).Once()
+				reviewerSelector.On("Select", ctx, smallTeam, []string{"user2", "user3"}, 1).Return([]string{"user3"}, nil).Once()
+				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest"), (*time.Time)(nil)).Run(func(args mock.Arguments) {
+					pr := args.Get(1).(*model.PullRequest)
+					assert.Equal(t, []string{"user3"}, pr.AssignedReviewers)
+				}).Return(&model.PullRequest{}, nil).Once()
+			},
+			expectedError:  "",
+			expectedEvents: []model.EventType{model.EventPullRequestCreated},
+		},
+		{
+			name:     "Error not enough candidates for team minimum",
+			prID:     "pr1",
+			prName:   "New Feature",
+			authorID: "author1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(strictTeam, nil).Once()
+			},
+			expectedError: "not enough active reviewer candidates in team",
 		},
 		{
 			name:     "Error user not found",
 			prID:     "pr1",
 			prName:   "New Feature",
 			authorID: "author1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
 				userService.On("GetUserByID", ctx, "author1").Return(nil, model.ErrUserNotFound).Once()
 			},
 			expectedError: "get author: user not found",
@@ -69,7 +182,7 @@ func TestPullRequestService_Create(t *testing.T) {
 			prID:     "pr1",
 			prName:   "New Feature",
 			authorID: "author1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
 				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
 				teamService.On("GetTeamByName", ctx, "team-a").Return(nil, model.ErrTeamNotFound).Once()
 			},
@@ -80,10 +193,11 @@ func TestPullRequestService_Create(t *testing.T) {
 			prID:     "pr1",
 			prName:   "New Feature",
 			authorID: "author1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
 				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
 				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
-				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest")).Return(nil, errors.New("db error")).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("Create", ctx, mock.AnythingOfType("*model.PullRequest"), (*time.Time)(nil)).Return(nil, errors.New("db error")).Once()
 			},
 			expectedError: "create PR: db error",
 		},
@@ -94,48 +208,169 @@ func TestPullRequestService_Create(t *testing.T) {
 			pullRequestRepository := new(mocks.PullRequestRepository)
 			userService := new(mocks.UserService)
 			teamService := new(mocks.TeamService)
-			test.setupMocks(pullRequestRepository, userService, teamService)
+			reviewerSelector := new(mocks.ReviewerSelector)
+			txManager := new(mocks.TxManager)
+			eventPublisher := new(mocks.EventPublisher)
+			test.setupMocks(pullRequestRepository, userService, teamService, reviewerSelector)
 
-			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService)
-			_, err := s.Create(ctx, test.prID, test.prName, test.authorID)
+			var published []model.EventType
+			eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Run(func(args mock.Arguments) {
+				published = append(published, args.Get(1).(model.Event).Type)
+			}).Return(test.publishErr).Maybe()
+
+			var audited []model.AuditEvent
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Run(func(args mock.Arguments) {
+				audited = append(audited, args.Get(1).(model.AuditEvent))
+			}).Return(nil).Maybe()
+
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, reviewerSelector, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+			_, err := s.Create(ctx, test.prID, test.prName, test.authorID, test.isDraft)
 
 			if test.expectedError != "" {
 				assert.EqualError(t, err, test.expectedError)
 			} else {
 				assert.NoError(t, err)
+				if assert.Len(t, audited, 1) {
+					assert.Equal(t, model.AuditActionCreate, audited[0].Action)
+					assert.Equal(t, test.prID, audited[0].PullRequestID)
+					assert.Equal(t, "team-a", audited[0].TeamName)
+				}
 			}
+			assert.Equal(t, test.expectedEvents, published)
 
 			pullRequestRepository.AssertExpectations(t)
 			userService.AssertExpectations(t)
 			teamService.AssertExpectations(t)
+			reviewerSelector.AssertExpectations(t)
 		})
 	}
 }
 
 func TestPullRequestService_Merge(t *testing.T) {
 	ctx := context.Background()
-	prOpen := &model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestOpen}
+	author := &model.User{UserID: "author1", TeamName: "team-a", IsActive: true}
+	team := &model.Team{TeamName: "team-a"}
+	strictTeam := &model.Team{
+		TeamName: "team-a",
+		Settings: model.TeamSettings{
+			MaxReviewers: 2,
+			MergePolicy: model.MergePolicy{
+				MinApprovals:            2,
+				BlockOnChangesRequested: true,
+				AuthorCannotMerge:       true,
+			},
+		},
+	}
+	prOpen := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen}
+	prApproved := &model.PullRequest{
+		PullRequestID: "pr1",
+		AuthorID:      "author1",
+		Status:        model.PullRequestOpen,
+		Reviews: []model.Review{
+			{ReviewerID: "user2", State: model.ReviewStateApproved},
+			{ReviewerID: "user3", State: model.ReviewStateApproved},
+		},
+	}
+	prChangesRequested := &model.PullRequest{
+		PullRequestID: "pr1",
+		AuthorID:      "author1",
+		Status:        model.PullRequestOpen,
+		Reviews: []model.Review{
+			{ReviewerID: "user2", State: model.ReviewStateApproved},
+			{ReviewerID: "user3", State: model.ReviewStateChangesRequested},
+		},
+	}
 	prMerged := &model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestMerged}
+	prClosed := &model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestClosed}
+	prDraft := &model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestDraft}
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*mocks.PullRequestRepository)
+		setupMocks    func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService)
 		prID          string
+		opts          model.MergeOptions
+		auditErr      error
+		noSideEffects bool
 		expectedError string
 	}{
+		{
+			name: "Error - PR is draft",
+			prID: "pr1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prDraft, nil).Once()
+			},
+			expectedError: "pull request is a draft",
+		},
+		{
+			name: "Error - PR is closed",
+			prID: "pr1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prClosed, nil).Once()
+			},
+			expectedError: "pull request is closed",
+		},
 		{
 			name: "Success - Merge open PR",
 			prID: "pr1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
-				pullRequestRepository.On("Merge", ctx, "pr1").Return(prMerged, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{}).Return(prMerged, true, nil).Once()
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success - policy satisfied",
+			prID: "pr1",
+			opts: model.MergeOptions{MergedBy: "user2"},
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prApproved, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(strictTeam, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{MergedBy: "user2"}).Return(prMerged, true, nil).Once()
+			},
+			expectedError: "",
+		},
+		{
+			name: "Error - policy not satisfied",
+			prID: "pr1",
+			opts: model.MergeOptions{MergedBy: "author1"},
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prChangesRequested, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(strictTeam, nil).Once()
+			},
+			expectedError: "merge policy is not satisfied: 1 of 2 required approvals; changes requested by user3; author cannot merge own pull request",
+		},
+		{
+			name: "Success - admin override skips policy",
+			prID: "pr1",
+			opts: model.MergeOptions{MergedBy: "admin", Override: true},
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prChangesRequested, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{MergedBy: "admin", Override: true}).Return(prMerged, true, nil).Once()
 			},
 			expectedError: "",
 		},
+		{
+			name:     "Error on audit record",
+			prID:     "pr1",
+			opts:     model.MergeOptions{MergedBy: "admin", Override: true},
+			auditErr: errors.New("db error"),
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{MergedBy: "admin", Override: true}).Return(prMerged, true, nil).Once()
+			},
+			expectedError: "record merge audit event: db error",
+		},
 		{
 			name: "Success - PR already merged",
 			prID: "pr1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 				pullRequestRepository.On("Get", ctx, "pr1").Return(prMerged, nil).Once()
 			},
 			expectedError: "",
@@ -143,7 +378,7 @@ func TestPullRequestService_Merge(t *testing.T) {
 		{
 			name: "Error - PR not found on Get",
 			prID: "pr1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 				pullRequestRepository.On("Get", ctx, "pr1").Return(nil, model.ErrPullRequestNotFound).Once()
 			},
 			expectedError: "get PR to merge: pull request not found",
@@ -151,116 +386,136 @@ func TestPullRequestService_Merge(t *testing.T) {
 		{
 			name: "Error - PR not found on Merge",
 			prID: "pr1",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
-				pullRequestRepository.On("Merge", ctx, "pr1").Return(nil, model.ErrPullRequestNotFound).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{}).Return(nil, false, model.ErrPullRequestNotFound).Once()
 			},
 			expectedError: "merge PR: pull request not found",
 		},
+		{
+			name: "Error - PR closed after the status check",
+			prID: "pr1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{}).Return(nil, false, model.ErrPRClosed).Once()
+			},
+			noSideEffects: true,
+			expectedError: "merge PR: pull request is closed",
+		},
+		{
+			name: "Success - concurrent merge is not repeated",
+			prID: "pr1",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{}).Return(prMerged, false, nil).Once()
+			},
+			noSideEffects: true,
+			expectedError: "",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			pullRequestRepository := new(mocks.PullRequestRepository)
-			test.setupMocks(pullRequestRepository)
+			userService := new(mocks.UserService)
+			teamService := new(mocks.TeamService)
+			test.setupMocks(pullRequestRepository, userService, teamService)
 
-			s := pullrequest.NewPullRequestService(pullRequestRepository, nil, nil)
-			_, err := s.Merge(ctx, test.prID)
+			txManager := new(mocks.TxManager)
+			eventPublisher := new(mocks.EventPublisher)
+			eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", ctx, mock.MatchedBy(func(event model.AuditEvent) bool {
+				return event.Action == model.AuditActionMerge && event.PullRequestID == "pr1" && event.TeamName == "team-a" && event.After == prMerged
+			})).Return(test.auditErr).Maybe()
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, nil, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+			_, err := s.Merge(ctx, test.prID, test.opts)
 
 			if test.expectedError != "" {
 				assert.EqualError(t, err, test.expectedError)
 			} else {
 				assert.NoError(t, err)
 			}
+			if test.noSideEffects {
+				eventPublisher.AssertNotCalled(t, "Publish", ctx, mock.Anything)
+				auditRecorder.AssertNotCalled(t, "Record", ctx, mock.Anything)
+			}
 			pullRequestRepository.AssertExpectations(t)
+			userService.AssertExpectations(t)
+			teamService.AssertExpectations(t)
 		})
 	}
 }
 
-func TestPullRequestService_Reassign(t *testing.T) {
-	ctx := context.Background()
-	now := time.Now()
-	prOpen := &model.PullRequest{
-		PullRequestID:     "pr1",
-		Status:            model.PullRequestOpen,
-		AuthorID:          "author1",
-		AssignedReviewers: []string{"old_reviewer", "other_reviewer"},
-	}
-	prMerged := &model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestMerged, MergedAt: &now}
-	oldReviewer := &model.User{UserID: "old_reviewer", TeamName: "team-a", IsActive: true}
-	team := &model.Team{
+func TestPullRequestService_MergeCaller(t *testing.T) {
+	authorCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "author1", UserID: "author1", Role: model.RoleMember})
+	reviewerCtx := principal.WithPrincipal(context.Background(), &model.Principal{Subject: "user2", UserID: "user2", Role: model.RoleMember})
+	author := &model.User{UserID: "author1", TeamName: "team-a", IsActive: true}
+	strictTeam := &model.Team{
 		TeamName: "team-a",
-		Users: []model.User{
-			{UserID: "author1"},
-			*oldReviewer,
-			{UserID: "other_reviewer"},
-			{UserID: "new_reviewer", IsActive: true},
-			{UserID: "inactive_reviewer", IsActive: false},
-		},
-	}
-	prWithNewReviewer := &model.PullRequest{
-		PullRequestID:     "pr1",
-		Status:            model.PullRequestOpen,
-		AuthorID:          "author1",
-		AssignedReviewers: []string{"new_reviewer", "other_reviewer"},
+		Settings: model.TeamSettings{MergePolicy: model.MergePolicy{AuthorCannotMerge: true}},
 	}
+	prOpen := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen}
+	prMerged := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestMerged}
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService)
-		prID          string
-		oldReviewerID string
+		ctx           context.Context
+		opts          model.MergeOptions
+		setupMocks    func(context.Context, *mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService)
 		expectedError string
 	}{
 		{
-			name:          "Success",
-			prID:          "pr1",
-			oldReviewerID: "old_reviewer",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			name: "Error - author without merged_by is bound to the caller",
+			ctx:  authorCtx,
+			setupMocks: func(ctx context.Context, pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
-				userService.On("GetUserByID", ctx, "old_reviewer").Return(oldReviewer, nil).Once()
-				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
-				pullRequestRepository.On("UpdateReviewer", ctx, "pr1", "old_reviewer", "new_reviewer").Return(nil).Once()
-				pullRequestRepository.On("Get", ctx, "pr1").Return(prWithNewReviewer, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(strictTeam, nil).Once()
 			},
-			expectedError: "",
+			expectedError: "merge policy is not satisfied: author cannot merge own pull request",
 		},
 		{
-			name:          "Error - PR is merged",
-			prID:          "pr1",
-			oldReviewerID: "old_reviewer",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
-				pullRequestRepository.On("Get", ctx, "pr1").Return(prMerged, nil).Once()
+			name: "Error - author claims another merger",
+			ctx:  authorCtx,
+			opts: model.MergeOptions{MergedBy: "user2"},
+			setupMocks: func(ctx context.Context, pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 			},
-			expectedError: "cannot reassign on merged PR",
+			expectedError: model.ErrForbidden.Error(),
 		},
 		{
-			name:          "Error - Reviewer not assigned",
-			prID:          "pr1",
-			oldReviewerID: "not_assigned_reviewer",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			name: "Error - member requests override",
+			ctx:  reviewerCtx,
+			opts: model.MergeOptions{Override: true},
+			setupMocks: func(ctx context.Context, pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			},
+			expectedError: model.ErrForbidden.Error(),
+		},
+		{
+			name: "Success - reviewer merges as themselves",
+			ctx:  reviewerCtx,
+			setupMocks: func(ctx context.Context, pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(strictTeam, nil).Once()
+				pullRequestRepository.On("Merge", ctx, "pr1", model.MergeOptions{MergedBy: "user2"}).Return(prMerged, true, nil).Once()
 			},
-			expectedError: "reviewer is not assigned to this pull request",
 		},
 		{
-			name:          "Error - No candidates for reassign",
-			prID:          "pr1",
-			oldReviewerID: "old_reviewer",
-			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
+			name: "Error - merged_by required without caller",
+			ctx:  context.Background(),
+			setupMocks: func(ctx context.Context, pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService) {
 				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
-				userService.On("GetUserByID", ctx, "old_reviewer").Return(oldReviewer, nil).Once()
-				teamWithoutCandidates := &model.Team{
-					TeamName: "team-a",
-					Users: []model.User{
-						{UserID: "author1"},
-						*oldReviewer,
-						{UserID: "other_reviewer"},
-					},
-				}
-				teamService.On("GetTeamByName", ctx, "team-a").Return(teamWithoutCandidates, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(strictTeam, nil).Once()
 			},
-			expectedError: "no active replacement candidate in team",
+			expectedError: "merge policy is not satisfied: merged_by is required",
 		},
 	}
 
@@ -269,17 +524,20 @@ func TestPullRequestService_Reassign(t *testing.T) {
 			pullRequestRepository := new(mocks.PullRequestRepository)
 			userService := new(mocks.UserService)
 			teamService := new(mocks.TeamService)
-			test.setupMocks(pullRequestRepository, userService, teamService)
+			test.setupMocks(test.ctx, pullRequestRepository, userService, teamService)
 
-			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService)
-			_, err := s.Reassign(ctx, test.prID, test.oldReviewerID)
+			eventPublisher := new(mocks.EventPublisher)
+			eventPublisher.On("Publish", test.ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", test.ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Maybe()
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, nil, new(mocks.TxManager), eventPublisher, auditRecorder, sla.Calendar{})
+			_, err := s.Merge(test.ctx, "pr1", test.opts)
 
 			if test.expectedError != "" {
 				assert.EqualError(t, err, test.expectedError)
 			} else {
 				assert.NoError(t, err)
 			}
-
 			pullRequestRepository.AssertExpectations(t)
 			userService.AssertExpectations(t)
 			teamService.AssertExpectations(t)
@@ -287,48 +545,816 @@ func TestPullRequestService_Reassign(t *testing.T) {
 	}
 }
 
-func TestPullRequestService_GetPullRequestsForUserReview(t *testing.T) {
+func TestPullRequestService_CloseReopen(t *testing.T) {
 	ctx := context.Background()
-	userID := "user123"
+	author := &model.User{UserID: "author1", TeamName: "team-a", IsActive: true}
+	prOpen := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen}
+	prClosed := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestClosed}
+	prMerged := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestMerged}
+	prDraft := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestDraft}
 
 	tests := []struct {
-		name          string
-		setupMocks    func(*mocks.PullRequestRepository, *mocks.UserService)
-		userID        string
-		expectedPRs   []model.PullRequest
-		expectedError error
+		name           string
+		action         string
+		setupMocks     func(*mocks.PullRequestRepository, *mocks.UserService)
+		expectedAudit  model.AuditAction
+		auditErr       error
+		expectedStatus model.PullRequestStatus
+		expectedError  string
 	}{
 		{
-			name:   "Error - User not found",
-			userID: userID,
+			name:   "Success - close open PR",
+			action: "close",
 			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
-				userService.On("GetUserByID", ctx, userID).Return(nil, model.ErrUserNotFound).Once()
-				pullRequestRepository.AssertNotCalled(t, "GetUsersPullRequests", ctx, userID)
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Close", ctx, "pr1").Return(prClosed, true, nil).Once()
 			},
-			expectedPRs:   nil,
-			expectedError: model.ErrUserNotFound,
+			expectedAudit:  model.AuditActionClose,
+			expectedStatus: model.PullRequestClosed,
 		},
 		{
-			name:   "Error - UserService returns generic error",
-			userID: userID,
+			name:   "Success - close draft PR",
+			action: "close",
 			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
-				userService.On("GetUserByID", ctx, userID).Return(nil, errors.New("some user service error")).Once()
-				pullRequestRepository.AssertNotCalled(t, "GetUsersPullRequests", ctx, userID)
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prDraft, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Close", ctx, "pr1").Return(prClosed, true, nil).Once()
 			},
-			expectedPRs:   nil,
-			expectedError: errors.New("some user service error"),
+			expectedAudit:  model.AuditActionClose,
+			expectedStatus: model.PullRequestClosed,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			pullRequestRepository := new(mocks.PullRequestRepository)
-			userService := new(mocks.UserService)
-			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, nil)
-
-			test.setupMocks(pullRequestRepository, userService)
-
-			actualPRs, actualError := s.GetPullRequestsForUserReview(ctx, test.userID)
+		{
+			name:   "Success - reopen closed draft restores DRAFT",
+			action: "reopen",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prClosed, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Reopen", ctx, "pr1").Return(prDraft, true, nil).Once()
+			},
+			expectedAudit:  model.AuditActionReopen,
+			expectedStatus: model.PullRequestDraft,
+		},
+		{
+			name:   "Success - reopen draft PR",
+			action: "reopen",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prDraft, nil).Once()
+			},
+			expectedStatus: model.PullRequestDraft,
+		},
+		{
+			name:   "Success - close already closed PR",
+			action: "close",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prClosed, nil).Once()
+			},
+			expectedStatus: model.PullRequestClosed,
+		},
+		{
+			name:   "Error - close merged PR",
+			action: "close",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prMerged, nil).Once()
+			},
+			expectedError: "cannot close or reopen merged PR",
+		},
+		{
+			name:   "Success - reopen closed PR",
+			action: "reopen",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prClosed, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Reopen", ctx, "pr1").Return(prOpen, true, nil).Once()
+			},
+			expectedAudit:  model.AuditActionReopen,
+			expectedStatus: model.PullRequestOpen,
+		},
+		{
+			name:   "Success - reopen open PR",
+			action: "reopen",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+			},
+			expectedStatus: model.PullRequestOpen,
+		},
+		{
+			name:   "Error - reopen merged PR",
+			action: "reopen",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prMerged, nil).Once()
+			},
+			expectedError: "cannot close or reopen merged PR",
+		},
+		{
+			name:   "Error - PR not found",
+			action: "reopen",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(nil, model.ErrPullRequestNotFound).Once()
+			},
+			expectedError: "get PR to reopen: pull request not found",
+		},
+		{
+			name:   "Success - concurrent close is not audited",
+			action: "close",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Close", ctx, "pr1").Return(prClosed, false, nil).Once()
+			},
+			expectedStatus: model.PullRequestClosed,
+		},
+		{
+			name:   "Error - audit fails",
+			action: "close",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				pullRequestRepository.On("Close", ctx, "pr1").Return(prClosed, true, nil).Once()
+			},
+			expectedAudit: model.AuditActionClose,
+			auditErr:      errors.New("db error"),
+			expectedError: "record close audit event: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			userService := new(mocks.UserService)
+			test.setupMocks(pullRequestRepository, userService)
+
+			auditRecorder := new(mocks.AuditRecorder)
+			if test.expectedAudit != "" {
+				auditRecorder.On("Record", ctx, mock.MatchedBy(func(event model.AuditEvent) bool {
+					return event.Action == test.expectedAudit && event.PullRequestID == "pr1" && event.UserID == "author1" && event.TeamName == "team-a"
+				})).Return(test.auditErr).Once()
+			}
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, nil, nil, new(mocks.TxManager), nil, auditRecorder, sla.Calendar{})
+			var pr *model.PullRequest
+			var err error
+			if test.action == "close" {
+				pr, err = s.Close(ctx, "pr1")
+			} else {
+				pr, err = s.Reopen(ctx, "pr1")
+			}
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedStatus, pr.Status)
+			}
+			pullRequestRepository.AssertExpectations(t)
+			userService.AssertExpectations(t)
+			auditRecorder.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_MarkReady(t *testing.T) {
+	ctx := context.Background()
+	author := &model.User{UserID: "author1", TeamName: "team-a", IsActive: true}
+	team := &model.Team{
+		TeamName: "team-a",
+		Users: []model.User{
+			*author,
+			{UserID: "user2", IsActive: true},
+			{UserID: "user3", IsActive: true},
+		},
+		Settings: model.TeamSettings{MaxReviewers: 2},
+	}
+	strictTeam := &model.Team{
+		TeamName: "team-a",
+		Users:    team.Users,
+		Settings: model.TeamSettings{MinReviewers: 3, MaxReviewers: 3},
+	}
+	prDraft := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestDraft}
+	prOpen := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"user2", "user3"}}
+	prClosed := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestClosed}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService, *mocks.ReviewerSelector)
+		expected      *model.PullRequest
+		transitioned  bool
+		expectedError string
+	}{
+		{
+			name: "Success - reviewers assigned",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prDraft, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("MarkReady", ctx, "pr1", []string{"user2", "user3"}, (*time.Time)(nil)).Return(prOpen, true, nil).Once()
+			},
+			expected:     prOpen,
+			transitioned: true,
+		},
+		{
+			name: "Success - concurrent mark ready does not notify again",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prDraft, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("MarkReady", ctx, "pr1", []string{"user2", "user3"}, (*time.Time)(nil)).Return(prOpen, false, nil).Once()
+			},
+			expected: prOpen,
+		},
+		{
+			name: "Success - PR closed concurrently is returned as is",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prDraft, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("MarkReady", ctx, "pr1", []string{"user2", "user3"}, (*time.Time)(nil)).Return(prClosed, false, nil).Once()
+			},
+			expected: prClosed,
+		},
+		{
+			name: "Success - already open",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+			},
+			expected: prOpen,
+		},
+		{
+			name: "Error - PR is merged",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestMerged}, nil).Once()
+			},
+			expectedError: "cannot reassign on merged PR",
+		},
+		{
+			name: "Error - PR is closed",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestClosed}, nil).Once()
+			},
+			expectedError: "pull request is closed",
+		},
+		{
+			name: "Error - not enough candidates",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prDraft, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(strictTeam, nil).Once()
+			},
+			expectedError: "not enough active reviewer candidates in team",
+		},
+		{
+			name: "Error - PR not found",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(nil, model.ErrPullRequestNotFound).Once()
+			},
+			expectedError: "get PR to mark ready: pull request not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			userService := new(mocks.UserService)
+			teamService := new(mocks.TeamService)
+			reviewerSelector := new(mocks.ReviewerSelector)
+			test.setupMocks(pullRequestRepository, userService, teamService, reviewerSelector)
+
+			txManager := new(mocks.TxManager)
+			eventPublisher := new(mocks.EventPublisher)
+			eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Maybe()
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, reviewerSelector, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+			pr, err := s.MarkReady(ctx, "pr1")
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, pr)
+			}
+			if test.transitioned {
+				eventPublisher.AssertCalled(t, "Publish", ctx, mock.MatchedBy(func(event model.Event) bool {
+					return event.Type == model.EventReviewersAssigned
+				}))
+				auditRecorder.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(event model.AuditEvent) bool {
+					return event.Action == model.AuditActionMarkReady
+				}))
+			} else {
+				eventPublisher.AssertNotCalled(t, "Publish", ctx, mock.Anything)
+				auditRecorder.AssertNotCalled(t, "Record", ctx, mock.Anything)
+			}
+
+			pullRequestRepository.AssertExpectations(t)
+			userService.AssertExpectations(t)
+			teamService.AssertExpectations(t)
+			reviewerSelector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_Reassign(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	prOpen := &model.PullRequest{
+		PullRequestID:     "pr1",
+		Status:            model.PullRequestOpen,
+		AuthorID:          "author1",
+		AssignedReviewers: []string{"old_reviewer", "other_reviewer"},
+	}
+	prMerged := &model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestMerged, MergedAt: &now}
+	oldReviewer := &model.User{UserID: "old_reviewer", TeamName: "team-a", IsActive: true}
+	team := &model.Team{
+		TeamName: "team-a",
+		Users: []model.User{
+			{UserID: "author1"},
+			*oldReviewer,
+			{UserID: "other_reviewer"},
+			{UserID: "new_reviewer", IsActive: true},
+			{UserID: "inactive_reviewer", IsActive: false},
+		},
+	}
+	prWithNewReviewer := &model.PullRequest{
+		PullRequestID:     "pr1",
+		Status:            model.PullRequestOpen,
+		AuthorID:          "author1",
+		AssignedReviewers: []string{"new_reviewer", "other_reviewer"},
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService, *mocks.ReviewerSelector)
+		prID          string
+		oldReviewerID string
+		expectedError string
+	}{
+		{
+			name:          "Success",
+			prID:          "pr1",
+			oldReviewerID: "old_reviewer",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "old_reviewer").Return(oldReviewer, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"new_reviewer"}, 1).Return([]string{"new_reviewer"}, nil).Once()
+				pullRequestRepository.On("UpdateReviewer", ctx, "pr1", "old_reviewer", "new_reviewer", model.AssignmentReasonReassign, (*time.Time)(nil)).Return(nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prWithNewReviewer, nil).Once()
+			},
+			expectedError: "",
+		},
+		{
+			name:          "Error - PR is merged",
+			prID:          "pr1",
+			oldReviewerID: "old_reviewer",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prMerged, nil).Once()
+			},
+			expectedError: "cannot reassign on merged PR",
+		},
+		{
+			name:          "Error - PR is closed",
+			prID:          "pr1",
+			oldReviewerID: "old_reviewer",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestClosed}, nil).Once()
+			},
+			expectedError: "pull request is closed",
+		},
+		{
+			name:          "Error - Reviewer not assigned",
+			prID:          "pr1",
+			oldReviewerID: "not_assigned_reviewer",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+			},
+			expectedError: "reviewer is not assigned to this pull request",
+		},
+		{
+			name:          "Error - No candidates for reassign",
+			prID:          "pr1",
+			oldReviewerID: "old_reviewer",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				userService.On("GetUserByID", ctx, "old_reviewer").Return(oldReviewer, nil).Once()
+				teamWithoutCandidates := &model.Team{
+					TeamName: "team-a",
+					Users: []model.User{
+						{UserID: "author1"},
+						*oldReviewer,
+						{UserID: "other_reviewer"},
+					},
+				}
+				teamService.On("GetTeamByName", ctx, "team-a").Return(teamWithoutCandidates, nil).Once()
+			},
+			expectedError: "no active replacement candidate in team",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			userService := new(mocks.UserService)
+			teamService := new(mocks.TeamService)
+			reviewerSelector := new(mocks.ReviewerSelector)
+			test.setupMocks(pullRequestRepository, userService, teamService, reviewerSelector)
+
+			txManager := new(mocks.TxManager)
+			eventPublisher := new(mocks.EventPublisher)
+			eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Maybe()
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, reviewerSelector, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+			_, err := s.Reassign(ctx, test.prID, test.oldReviewerID)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			pullRequestRepository.AssertExpectations(t)
+			userService.AssertExpectations(t)
+			teamService.AssertExpectations(t)
+			reviewerSelector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_SubmitReview(t *testing.T) {
+	ctx := context.Background()
+	prOpen := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"user2", "user3"}}
+	prReviewed := &model.PullRequest{
+		PullRequestID:     "pr1",
+		AuthorID:          "author1",
+		Status:            model.PullRequestOpen,
+		AssignedReviewers: []string{"user2", "user3"},
+		Reviews: []model.Review{
+			{ReviewerID: "user2", State: model.ReviewStateApproved},
+			{ReviewerID: "user3", State: model.ReviewStatePending},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		reviewerID    string
+		state         model.ReviewState
+		setupMocks    func(*mocks.PullRequestRepository)
+		expected      *model.PullRequest
+		expectedError string
+	}{
+		{
+			name:       "Success",
+			reviewerID: "user2",
+			state:      model.ReviewStateApproved,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				pullRequestRepository.On("SetReviewState", ctx, "pr1", "user2", model.ReviewStateApproved).Return(nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prReviewed, nil).Once()
+			},
+			expected: prReviewed,
+		},
+		{
+			name:          "Error - invalid state",
+			reviewerID:    "user2",
+			state:         model.ReviewStatePending,
+			setupMocks:    func(pullRequestRepository *mocks.PullRequestRepository) {},
+			expectedError: "state must be APPROVED or CHANGES_REQUESTED",
+		},
+		{
+			name:       "Error - reviewer not assigned",
+			reviewerID: "user4",
+			state:      model.ReviewStateChangesRequested,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+			},
+			expectedError: "reviewer is not assigned to this pull request",
+		},
+		{
+			name:       "Error - PR is merged",
+			reviewerID: "user2",
+			state:      model.ReviewStateApproved,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestMerged}, nil).Once()
+			},
+			expectedError: "cannot review merged PR",
+		},
+		{
+			name:       "Error - PR is closed",
+			reviewerID: "user2",
+			state:      model.ReviewStateApproved,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1", Status: model.PullRequestClosed}, nil).Once()
+			},
+			expectedError: "pull request is closed",
+		},
+		{
+			name:       "Error on set review state",
+			reviewerID: "user2",
+			state:      model.ReviewStateApproved,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(prOpen, nil).Once()
+				pullRequestRepository.On("SetReviewState", ctx, "pr1", "user2", model.ReviewStateApproved).Return(errors.New("db error")).Once()
+			},
+			expectedError: "set review state: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			test.setupMocks(pullRequestRepository)
+
+			s := pullrequest.NewPullRequestService(pullRequestRepository, nil, nil, nil, nil, nil, nil, sla.Calendar{})
+			pr, err := s.SubmitReview(ctx, "pr1", test.reviewerID, test.state)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, pr)
+			}
+			pullRequestRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_GetOverdueReviews(t *testing.T) {
+	ctx := context.Background()
+	overdue := []model.OverdueReview{
+		{PullRequestID: "pr1", ReviewerID: "user2", DueAt: time.Now().Add(-time.Hour)},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		pullRequestRepository := new(mocks.PullRequestRepository)
+		pullRequestRepository.On("GetOverdueReviews", ctx, mock.AnythingOfType("time.Time")).Return(overdue, nil).Once()
+
+		s := pullrequest.NewPullRequestService(pullRequestRepository, nil, nil, nil, nil, nil, nil, sla.Calendar{})
+		reviews, err := s.GetOverdueReviews(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, overdue, reviews)
+		pullRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error on repository", func(t *testing.T) {
+		pullRequestRepository := new(mocks.PullRequestRepository)
+		pullRequestRepository.On("GetOverdueReviews", ctx, mock.AnythingOfType("time.Time")).Return(nil, errors.New("db error")).Once()
+
+		s := pullrequest.NewPullRequestService(pullRequestRepository, nil, nil, nil, nil, nil, nil, sla.Calendar{})
+		_, err := s.GetOverdueReviews(ctx)
+
+		assert.EqualError(t, err, "get overdue reviews: db error")
+		pullRequestRepository.AssertExpectations(t)
+	})
+}
+
+func TestPullRequestService_GetHistory(t *testing.T) {
+	ctx := context.Background()
+	assignedAt := time.Date(2025, time.November, 19, 10, 0, 0, 0, time.UTC)
+	unassignedAt := assignedAt.Add(2 * time.Hour)
+	assignments := []model.ReviewerAssignment{
+		{ReviewerID: "user2", Reason: model.AssignmentReasonInitial, State: model.ReviewStatePending, AssignedAt: assignedAt, UnassignedAt: &unassignedAt},
+		{ReviewerID: "user3", Reason: model.AssignmentReasonReassign, State: model.ReviewStatePending, AssignedAt: unassignedAt},
+	}
+
+	tests := []struct {
+		name            string
+		setupMocks      func(*mocks.PullRequestRepository)
+		expectedHistory *model.PullRequestHistory
+		expectedError   string
+	}{
+		{
+			name: "Success",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1"}, nil).Once()
+				pullRequestRepository.On("GetReviewerHistory", ctx, "pr1").Return(assignments, nil).Once()
+			},
+			expectedHistory: &model.PullRequestHistory{PullRequestID: "pr1", Assignments: assignments},
+		},
+		{
+			name: "Error - PR not found",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(nil, model.ErrPullRequestNotFound).Once()
+			},
+			expectedError: "get PR: pull request not found",
+		},
+		{
+			name: "Error on history query",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository) {
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1"}, nil).Once()
+				pullRequestRepository.On("GetReviewerHistory", ctx, "pr1").Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "get reviewer history: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			test.setupMocks(pullRequestRepository)
+
+			s := pullrequest.NewPullRequestService(pullRequestRepository, nil, nil, nil, nil, nil, nil, sla.Calendar{})
+			history, err := s.GetHistory(ctx, "pr1")
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, history)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedHistory, history)
+			}
+			pullRequestRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_EscalateOverdueReviews(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Date(2025, time.November, 19, 14, 0, 0, 0, time.UTC)
+	reviewer := &model.User{UserID: "reviewer1", TeamName: "team-a", IsActive: true}
+	team := &model.Team{
+		TeamName: "team-a",
+		Users: []model.User{
+			{UserID: "author1", IsActive: true},
+			*reviewer,
+			{UserID: "user2", IsActive: true},
+			{UserID: "user3", IsActive: true},
+		},
+		Settings: model.TeamSettings{MaxReviewers: 2},
+	}
+	pr1 := &model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"reviewer1", "user2"}}
+	pr2 := &model.PullRequest{PullRequestID: "pr2", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"reviewer1", "user2", "user3"}}
+	overdue := []model.OverdueReview{
+		{PullRequestID: "pr1", AuthorID: "author1", ReviewerID: "reviewer1", DueAt: dueAt},
+		{PullRequestID: "pr2", AuthorID: "author1", ReviewerID: "reviewer1", DueAt: dueAt},
+	}
+
+	tests := []struct {
+		name            string
+		setupMocks      func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService, *mocks.ReviewerSelector)
+		expectedReport  *model.ReassignmentReport
+		expectedTxCalls int
+		expectedError   string
+	}{
+		{
+			name: "Success - reassigned and recorded, no candidate reported",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockOverdueReviews", ctx, mock.AnythingOfType("time.Time"), 10).Return(overdue, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(pr1, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr2").Return(pr2, nil).Once()
+				userService.On("GetUserByID", ctx, "reviewer1").Return(reviewer, nil).Twice()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user3"}, 1).Return([]string{"user3"}, nil).Once()
+				pullRequestRepository.On("UpdateReviewer", ctx, "pr1", "reviewer1", "user3", model.AssignmentReasonEscalation, (*time.Time)(nil)).Return(nil).Once()
+				pullRequestRepository.On("RecordEscalation", ctx, model.ReviewEscalation{
+					PullRequestID: "pr1",
+					OldReviewerID: "reviewer1",
+					NewReviewerID: "user3",
+					Reason:        "review SLA exceeded: no verdict by 2025-11-19T14:00:00Z",
+				}).Return(nil).Once()
+				pullRequestRepository.On("DeferEscalation", ctx, "pr2", "reviewer1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+			},
+			expectedReport: &model.ReassignmentReport{
+				Reassigned: []model.ReviewReassignment{
+					{PullRequestID: "pr1", OldReviewerID: "reviewer1", NewReviewerID: "user3"},
+				},
+				Failed: []model.ReassignmentFailure{
+					{PullRequestID: "pr2", ReviewerID: "reviewer1", Reason: "no active replacement candidate in team"},
+				},
+			},
+			expectedTxCalls: 3,
+		},
+		{
+			name: "Success - nothing overdue",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockOverdueReviews", ctx, mock.AnythingOfType("time.Time"), 10).Return([]model.OverdueReview{}, nil).Once()
+			},
+			expectedReport: &model.ReassignmentReport{
+				Reassigned: []model.ReviewReassignment{},
+				Failed:     []model.ReassignmentFailure{},
+			},
+			expectedTxCalls: 1,
+		},
+		{
+			name: "Error on lock",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockOverdueReviews", ctx, mock.AnythingOfType("time.Time"), 10).Return(nil, errors.New("db error")).Once()
+			},
+			expectedTxCalls: 1,
+			expectedError:   "lock overdue reviews: db error",
+		},
+		{
+			name: "Success - unexpected error deferred and isolated",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockOverdueReviews", ctx, mock.AnythingOfType("time.Time"), 10).Return(overdue[:1], nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(pr1, nil).Once()
+				userService.On("GetUserByID", ctx, "reviewer1").Return(reviewer, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user3"}, 1).Return([]string{"user3"}, nil).Once()
+				pullRequestRepository.On("UpdateReviewer", ctx, "pr1", "reviewer1", "user3", model.AssignmentReasonEscalation, (*time.Time)(nil)).Return(nil).Once()
+				pullRequestRepository.On("RecordEscalation", ctx, mock.Anything).Return(errors.New("db error")).Once()
+				pullRequestRepository.On("DeferEscalation", ctx, "pr1", "reviewer1", mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+					assert.WithinDuration(t, time.Now().Add(30*time.Minute), args.Get(3).(time.Time), 5*time.Second)
+				}).Return(nil).Once()
+			},
+			expectedReport: &model.ReassignmentReport{
+				Reassigned: []model.ReviewReassignment{},
+				Failed: []model.ReassignmentFailure{
+					{PullRequestID: "pr1", ReviewerID: "reviewer1", Reason: "record escalation: db error"},
+				},
+			},
+			expectedTxCalls: 2,
+		},
+		{
+			name: "Error on defer escalation",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockOverdueReviews", ctx, mock.AnythingOfType("time.Time"), 10).Return(overdue[1:], nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr2").Return(pr2, nil).Once()
+				userService.On("GetUserByID", ctx, "reviewer1").Return(reviewer, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				pullRequestRepository.On("DeferEscalation", ctx, "pr2", "reviewer1", mock.AnythingOfType("time.Time")).Return(errors.New("db error")).Once()
+			},
+			expectedTxCalls: 2,
+			expectedError:   "defer escalation of reviewer1 on PR pr2: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			userService := new(mocks.UserService)
+			teamService := new(mocks.TeamService)
+			reviewerSelector := new(mocks.ReviewerSelector)
+			txManager := new(mocks.TxManager)
+			test.setupMocks(pullRequestRepository, userService, teamService, reviewerSelector)
+
+			eventPublisher := new(mocks.EventPublisher)
+			eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Maybe()
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, reviewerSelector, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+			report, err := s.EscalateOverdueReviews(ctx, 10)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedReport, report)
+			}
+			assert.Equal(t, test.expectedTxCalls, txManager.Calls)
+
+			pullRequestRepository.AssertExpectations(t)
+			userService.AssertExpectations(t)
+			teamService.AssertExpectations(t)
+			reviewerSelector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_GetPullRequestsForUserReview(t *testing.T) {
+	ctx := context.Background()
+	userID := "user123"
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.PullRequestRepository, *mocks.UserService)
+		userID        string
+		expectedPRs   []model.PullRequest
+		expectedError error
+	}{
+		{
+			name:   "Error - User not found",
+			userID: userID,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				userService.On("GetUserByID", ctx, userID).Return(nil, model.ErrUserNotFound).Once()
+				pullRequestRepository.AssertNotCalled(t, "GetUsersPullRequests", ctx, userID)
+			},
+			expectedPRs:   nil,
+			expectedError: model.ErrUserNotFound,
+		},
+		{
+			name:   "Error - UserService returns generic error",
+			userID: userID,
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService) {
+				userService.On("GetUserByID", ctx, userID).Return(nil, errors.New("some user service error")).Once()
+				pullRequestRepository.AssertNotCalled(t, "GetUsersPullRequests", ctx, userID)
+			},
+			expectedPRs:   nil,
+			expectedError: errors.New("some user service error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			userService := new(mocks.UserService)
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, nil, nil, nil, nil, nil, sla.Calendar{})
+
+			test.setupMocks(pullRequestRepository, userService)
+
+			actualPRs, actualError := s.GetPullRequestsForUserReview(ctx, test.userID)
 
 			assert.Equal(t, test.expectedPRs, actualPRs)
 			assert.Equal(t, test.expectedError, actualError)
@@ -338,3 +1364,295 @@ func TestPullRequestService_GetPullRequestsForUserReview(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequestService_FillMissingReviewers(t *testing.T) {
+	ctx := context.Background()
+	author := &model.User{UserID: "author1", TeamName: "team-a", IsActive: true}
+	team := &model.Team{
+		TeamName: "team-a",
+		Users: []model.User{
+			*author,
+			{UserID: "user2", IsActive: true},
+			{UserID: "user3", IsActive: true},
+			{UserID: "user4", IsActive: false},
+		},
+		Settings: model.TeamSettings{MaxReviewers: 2},
+	}
+	prEmpty := model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{}}
+	prHalf := model.PullRequest{PullRequestID: "pr2", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"user2"}}
+	busyPR := model.PullRequest{PullRequestID: "pr3", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"user2", "user3"}}
+	bigTeam := &model.Team{TeamName: "team-a", Users: team.Users, Settings: model.TeamSettings{MaxReviewers: 3}}
+	fresh := func(pr model.PullRequest) *model.PullRequest {
+		return &pr
+	}
+
+	tests := []struct {
+		name              string
+		setupMocks        func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService, *mocks.ReviewerSelector)
+		expectedAnnounced map[string][]string
+		expectedError     string
+	}{
+		{
+			name: "Success - fills up to team target",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockUnderstaffedPullRequests", ctx, 10).Return([]model.PullRequest{prEmpty, prHalf}, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(fresh(prEmpty), nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr2").Return(fresh(prHalf), nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Twice()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("AddReviewers", ctx, "pr1", []string{"user2", "user3"}, (*time.Time)(nil)).Return([]string{"user2", "user3"}, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user3"}, 1).Return([]string{"user3"}, nil).Once()
+				pullRequestRepository.On("AddReviewers", ctx, "pr2", []string{"user3"}, (*time.Time)(nil)).Return([]string{"user3"}, nil).Once()
+			},
+			expectedAnnounced: map[string][]string{"pr1": {"user2", "user3"}, "pr2": {"user3"}},
+		},
+		{
+			name: "Success - only inserted reviewers are announced",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockUnderstaffedPullRequests", ctx, 10).Return([]model.PullRequest{prEmpty}, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(fresh(prEmpty), nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("AddReviewers", ctx, "pr1", []string{"user2", "user3"}, (*time.Time)(nil)).Return([]string{"user3"}, nil).Once()
+			},
+			expectedAnnounced: map[string][]string{"pr1": {"user3"}},
+		},
+		{
+			name: "Success - PR filled concurrently is skipped",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockUnderstaffedPullRequests", ctx, 10).Return([]model.PullRequest{prEmpty}, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(&model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"user2", "user3"}}, nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+			},
+			expectedAnnounced: map[string][]string{},
+		},
+		{
+			name: "Success - no free candidates is deferred",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockUnderstaffedPullRequests", ctx, 10).Return([]model.PullRequest{busyPR}, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr3").Return(fresh(busyPR), nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(bigTeam, nil).Once()
+				pullRequestRepository.On("DeferFill", ctx, "pr3", mock.AnythingOfType("time.Time")).Return(nil).Once()
+			},
+			expectedAnnounced: map[string][]string{},
+		},
+		{
+			name: "Error - failed PR does not stop others",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockUnderstaffedPullRequests", ctx, 10).Return([]model.PullRequest{prEmpty, prHalf}, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr1").Return(fresh(prEmpty), nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr2").Return(fresh(prHalf), nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Twice()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user2", "user3"}, 2).Return([]string{"user2", "user3"}, nil).Once()
+				pullRequestRepository.On("AddReviewers", ctx, "pr1", []string{"user2", "user3"}, (*time.Time)(nil)).Return(nil, errors.New("db error")).Once()
+				pullRequestRepository.On("DeferFill", ctx, "pr1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+				reviewerSelector.On("Select", ctx, team, []string{"user3"}, 1).Return([]string{"user3"}, nil).Once()
+				pullRequestRepository.On("AddReviewers", ctx, "pr2", []string{"user3"}, (*time.Time)(nil)).Return([]string{"user3"}, nil).Once()
+			},
+			expectedAnnounced: map[string][]string{"pr2": {"user3"}},
+			expectedError:     "fill reviewers for PR pr1: add reviewers: db error",
+		},
+		{
+			name: "Error - defer fill fails",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockUnderstaffedPullRequests", ctx, 10).Return([]model.PullRequest{busyPR}, nil).Once()
+				pullRequestRepository.On("Get", ctx, "pr3").Return(fresh(busyPR), nil).Once()
+				userService.On("GetUserByID", ctx, "author1").Return(author, nil).Once()
+				teamService.On("GetTeamByName", ctx, "team-a").Return(bigTeam, nil).Once()
+				pullRequestRepository.On("DeferFill", ctx, "pr3", mock.AnythingOfType("time.Time")).Return(errors.New("db error")).Once()
+			},
+			expectedError: "defer fill of PR pr3: db error",
+		},
+		{
+			name: "Error - understaffed query fails",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				pullRequestRepository.On("LockUnderstaffedPullRequests", ctx, 10).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "lock understaffed PRs: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			userService := new(mocks.UserService)
+			teamService := new(mocks.TeamService)
+			reviewerSelector := new(mocks.ReviewerSelector)
+			test.setupMocks(pullRequestRepository, userService, teamService, reviewerSelector)
+
+			txManager := new(mocks.TxManager)
+			eventPublisher := new(mocks.EventPublisher)
+			eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Maybe()
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, reviewerSelector, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+			err := s.FillMissingReviewers(ctx, 10)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if test.expectedAnnounced != nil {
+				announced := map[string][]string{}
+				for _, call := range eventPublisher.Calls {
+					event := call.Arguments.Get(1).(model.Event)
+					announced[event.PullRequest.PullRequestID] = event.Reviewers
+				}
+				assert.Equal(t, test.expectedAnnounced, announced)
+			}
+
+			pullRequestRepository.AssertExpectations(t)
+			userService.AssertExpectations(t)
+			teamService.AssertExpectations(t)
+			reviewerSelector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_ReassignReviews(t *testing.T) {
+	ctx := context.Background()
+	team := &model.Team{
+		TeamName: "team-a",
+		Users: []model.User{
+			{UserID: "author1", IsActive: true},
+			{UserID: "reviewer1", IsActive: false},
+			{UserID: "reviewer2", IsActive: false},
+			{UserID: "user2", IsActive: true},
+			{UserID: "user3", IsActive: true},
+		},
+	}
+	pr1 := model.PullRequest{PullRequestID: "pr1", AuthorID: "author1", Status: model.PullRequestOpen, AssignedReviewers: []string{"reviewer1", "reviewer2"}}
+	pr2 := model.PullRequest{PullRequestID: "pr2", AuthorID: "user3", Status: model.PullRequestOpen, AssignedReviewers: []string{"reviewer1", "user2"}}
+	userIDs := []string{"reviewer1", "reviewer2"}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.PullRequestRepository, *mocks.UserService, *mocks.TeamService, *mocks.ReviewerSelector)
+		expectedReport *model.ReassignmentReport
+		expectedError  string
+	}{
+		{
+			name: "Success - reassigns where possible and reports the rest",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				pullRequestRepository.On("GetOpenReviewsByUsers", ctx, userIDs).Return([]model.PullRequest{pr1, pr2}, nil).Once()
+				pullRequestRepository.On("GetOpenReviewCounts", ctx, []string{"author1", "user2", "user3"}).Return(map[string]int{"user2": 1}, nil).Once()
+				reviewerSelector.On("SelectWithLoads", ctx, team, []string{"user2", "user3"}, 1, mock.Anything).Return([]string{"user3"}).Once()
+				reviewerSelector.On("SelectWithLoads", ctx, team, []string{"user2"}, 1, mock.Anything).Return([]string{"user2"}).Once()
+				reviewerSelector.On("SelectWithLoads", ctx, team, []string{"author1"}, 1, mock.Anything).Return([]string{"author1"}).Once()
+				pullRequestRepository.On("ReplaceReviewers", ctx, []model.ReviewReassignment{
+					{PullRequestID: "pr1", OldReviewerID: "reviewer1", NewReviewerID: "user3"},
+					{PullRequestID: "pr1", OldReviewerID: "reviewer2", NewReviewerID: "user2"},
+					{PullRequestID: "pr2", OldReviewerID: "reviewer1", NewReviewerID: "author1"},
+				}, model.AssignmentReasonDeactivation, (*time.Time)(nil)).Return(nil).Once()
+			},
+			expectedReport: &model.ReassignmentReport{
+				Reassigned: []model.ReviewReassignment{
+					{PullRequestID: "pr1", OldReviewerID: "reviewer1", NewReviewerID: "user3"},
+					{PullRequestID: "pr1", OldReviewerID: "reviewer2", NewReviewerID: "user2"},
+					{PullRequestID: "pr2", OldReviewerID: "reviewer1", NewReviewerID: "author1"},
+				},
+				Failed: []model.ReassignmentFailure{},
+			},
+		},
+		{
+			name: "Success - no candidates is reported",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				smallTeam := &model.Team{TeamName: "team-a", Users: []model.User{{UserID: "author1", IsActive: true}}}
+				teamService.On("GetTeamByName", ctx, "team-a").Return(smallTeam, nil).Once()
+				pullRequestRepository.On("GetOpenReviewsByUsers", ctx, userIDs).Return([]model.PullRequest{pr1}, nil).Once()
+				pullRequestRepository.On("GetOpenReviewCounts", ctx, []string{"author1"}).Return(map[string]int{}, nil).Once()
+				reviewerSelector.On("SelectWithLoads", ctx, smallTeam, []string{}, 1, mock.Anything).Return([]string{}).Twice()
+			},
+			expectedReport: &model.ReassignmentReport{
+				Reassigned: []model.ReviewReassignment{},
+				Failed: []model.ReassignmentFailure{
+					{PullRequestID: "pr1", ReviewerID: "reviewer1", Reason: "no active replacement candidate in team"},
+					{PullRequestID: "pr1", ReviewerID: "reviewer2", Reason: "no active replacement candidate in team"},
+				},
+			},
+		},
+		{
+			name: "Error - replace reviewers fails",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+				pullRequestRepository.On("GetOpenReviewsByUsers", ctx, userIDs).Return([]model.PullRequest{pr2}, nil).Once()
+				pullRequestRepository.On("GetOpenReviewCounts", ctx, []string{"author1", "user2", "user3"}).Return(map[string]int{}, nil).Once()
+				reviewerSelector.On("SelectWithLoads", ctx, team, []string{"author1"}, 1, mock.Anything).Return([]string{"author1"}).Once()
+				pullRequestRepository.On("ReplaceReviewers", ctx, mock.Anything, model.AssignmentReasonDeactivation, (*time.Time)(nil)).Return(model.ErrReviewerNotAssigned).Once()
+			},
+			expectedError: "replace reviewers: reviewer is not assigned to this pull request",
+		},
+		{
+			name: "Error - team not found",
+			setupMocks: func(pullRequestRepository *mocks.PullRequestRepository, userService *mocks.UserService, teamService *mocks.TeamService, reviewerSelector *mocks.ReviewerSelector) {
+				teamService.On("GetTeamByName", ctx, "team-a").Return(nil, model.ErrTeamNotFound).Once()
+			},
+			expectedError: "get team: team not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullRequestRepository := new(mocks.PullRequestRepository)
+			userService := new(mocks.UserService)
+			teamService := new(mocks.TeamService)
+			reviewerSelector := new(mocks.ReviewerSelector)
+			test.setupMocks(pullRequestRepository, userService, teamService, reviewerSelector)
+
+			txManager := new(mocks.TxManager)
+			eventPublisher := new(mocks.EventPublisher)
+			eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+			auditRecorder := new(mocks.AuditRecorder)
+			auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Maybe()
+			s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, reviewerSelector, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+			report, err := s.ReassignReviews(ctx, "team-a", userIDs)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedReport, report)
+			}
+
+			pullRequestRepository.AssertExpectations(t)
+			userService.AssertExpectations(t)
+			teamService.AssertExpectations(t)
+			reviewerSelector.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestService_ReassignUserReviews(t *testing.T) {
+	ctx := context.Background()
+	pullRequestRepository := new(mocks.PullRequestRepository)
+	userService := new(mocks.UserService)
+	teamService := new(mocks.TeamService)
+	reviewerSelector := new(mocks.ReviewerSelector)
+	team := &model.Team{TeamName: "team-a", Users: []model.User{{UserID: "reviewer1"}, {UserID: "user2", IsActive: true}}}
+
+	userService.On("GetUserByID", ctx, "reviewer1").Return(&model.User{UserID: "reviewer1", TeamName: "team-a"}, nil).Once()
+	teamService.On("GetTeamByName", ctx, "team-a").Return(team, nil).Once()
+	pullRequestRepository.On("GetOpenReviewsByUsers", ctx, []string{"reviewer1"}).Return([]model.PullRequest{}, nil).Once()
+
+	txManager := new(mocks.TxManager)
+	eventPublisher := new(mocks.EventPublisher)
+	eventPublisher.On("Publish", ctx, mock.AnythingOfType("model.Event")).Return(nil).Maybe()
+	auditRecorder := new(mocks.AuditRecorder)
+	auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Maybe()
+	s := pullrequest.NewPullRequestService(pullRequestRepository, userService, teamService, reviewerSelector, txManager, eventPublisher, auditRecorder, sla.Calendar{})
+	report, err := s.ReassignUserReviews(ctx, "reviewer1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Reassigned)
+	assert.Empty(t, report.Failed)
+	pullRequestRepository.AssertExpectations(t)
+	userService.AssertExpectations(t)
+	teamService.AssertExpectations(t)
+}
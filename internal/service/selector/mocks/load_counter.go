@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LoadCounter struct {
+	mock.Mock
+}
+
+func (m *LoadCounter) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, userIDs)
+	var counts map[string]int
+	if args.Get(0) != nil {
+		counts = args.Get(0).(map[string]int)
+	}
+	return counts, args.Error(1)
+}
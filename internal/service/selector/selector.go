@@ -0,0 +1,65 @@
+package selector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type LoadCounter interface {
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+type Strategy interface {
+	Select(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string
+}
+
+type ReviewerSelector struct {
+	loadCounter     LoadCounter
+	strategies      map[model.ReviewerStrategy]Strategy
+	defaultStrategy model.ReviewerStrategy
+}
+
+func NewReviewerSelector(loadCounter LoadCounter) *ReviewerSelector {
+	return &ReviewerSelector{
+		loadCounter: loadCounter,
+		strategies: map[model.ReviewerStrategy]Strategy{
+			model.ReviewerStrategyRandom:      NewRandomStrategy(),
+			model.ReviewerStrategyRoundRobin:  NewRoundRobinStrategy(),
+			model.ReviewerStrategyLeastLoaded: NewLeastLoadedStrategy(),
+			model.ReviewerStrategyWeighted:    NewWeightedStrategy(),
+		},
+		defaultStrategy: model.ReviewerStrategyLeastLoaded,
+	}
+}
+
+func (s *ReviewerSelector) Select(ctx context.Context, team *model.Team, candidates []string, count int) ([]string, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil
+	}
+
+	loads, err := s.loadCounter.GetOpenReviewCounts(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("get open review counts: %w", err)
+	}
+
+	return s.SelectWithLoads(ctx, team, candidates, count, loads), nil
+}
+
+func (s *ReviewerSelector) SelectWithLoads(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}
+	}
+
+	strategy, ok := s.strategies[team.Settings.ReviewerStrategy]
+	if !ok {
+		strategy = s.strategies[s.defaultStrategy]
+	}
+
+	selected := strategy.Select(ctx, team, append([]string(nil), candidates...), count, loads)
+	if len(selected) > count {
+		return selected[:count]
+	}
+	return selected
+}
@@ -0,0 +1,170 @@
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/selector"
+	"github.com/avito/internship/pr-service/internal/service/selector/mocks"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReviewerSelector_Select(t *testing.T) {
+	ctx := context.Background()
+	candidates := []string{"user1", "user2", "user3"}
+
+	tests := []struct {
+		name          string
+		strategy      model.ReviewerStrategy
+		count         int
+		setupMocks    func(*mocks.LoadCounter)
+		expected      []string
+		expectedLen   int
+		expectedError string
+	}{
+		{
+			name:     "Least loaded picks reviewers with fewer open reviews",
+			strategy: model.ReviewerStrategyLeastLoaded,
+			count:    2,
+			setupMocks: func(loadCounter *mocks.LoadCounter) {
+				loadCounter.On("GetOpenReviewCounts", ctx, mock.Anything).Return(map[string]int{"user1": 5, "user3": 1}, nil).Once()
+			},
+			expected: []string{"user2", "user3"},
+		},
+		{
+			name:     "Unknown strategy falls back to least loaded",
+			strategy: "",
+			count:    1,
+			setupMocks: func(loadCounter *mocks.LoadCounter) {
+				loadCounter.On("GetOpenReviewCounts", ctx, mock.Anything).Return(map[string]int{"user1": 2, "user2": 2}, nil).Once()
+			},
+			expected: []string{"user3"},
+		},
+		{
+			name:        "Random returns requested count",
+			strategy:    model.ReviewerStrategyRandom,
+			count:       2,
+			expectedLen: 2,
+			setupMocks: func(loadCounter *mocks.LoadCounter) {
+				loadCounter.On("GetOpenReviewCounts", ctx, mock.Anything).Return(map[string]int{}, nil).Once()
+			},
+		},
+		{
+			name:        "Weighted never returns duplicates",
+			strategy:    model.ReviewerStrategyWeighted,
+			count:       3,
+			expectedLen: 3,
+			setupMocks: func(loadCounter *mocks.LoadCounter) {
+				loadCounter.On("GetOpenReviewCounts", ctx, mock.Anything).Return(map[string]int{"user1": 10}, nil).Once()
+			},
+		},
+		{
+			name:     "Error on open review counts",
+			strategy: model.ReviewerStrategyLeastLoaded,
+			count:    2,
+			setupMocks: func(loadCounter *mocks.LoadCounter) {
+				loadCounter.On("GetOpenReviewCounts", ctx, mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "get open review counts: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadCounter := new(mocks.LoadCounter)
+			test.setupMocks(loadCounter)
+
+			s := selector.NewReviewerSelector(loadCounter)
+			team := &model.Team{TeamName: "team-a", Settings: model.TeamSettings{ReviewerStrategy: test.strategy}}
+			selected, err := s.Select(ctx, team, append([]string(nil), candidates...), test.count)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				if test.expected != nil {
+					assert.ElementsMatch(t, test.expected, selected)
+				} else {
+					assert.Len(t, selected, test.expectedLen)
+				}
+				assert.Subset(t, candidates, selected)
+				seen := make(map[string]bool)
+				for _, reviewer := range selected {
+					assert.False(t, seen[reviewer])
+					seen[reviewer] = true
+				}
+			}
+
+			loadCounter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReviewerSelector_RoundRobin(t *testing.T) {
+	ctx := context.Background()
+	s := selector.NewReviewerSelector(new(mocks.LoadCounter))
+	team := &model.Team{TeamName: "team-a", Settings: model.TeamSettings{ReviewerStrategy: model.ReviewerStrategyRoundRobin}}
+	candidates := []string{"user3", "user1", "user2"}
+
+	first := s.SelectWithLoads(ctx, team, candidates, 2, nil)
+	assert.Equal(t, []string{"user1", "user2"}, first)
+
+	second := s.SelectWithLoads(ctx, team, candidates, 2, nil)
+	assert.Equal(t, []string{"user3", "user1"}, second)
+
+	assert.Equal(t, []string{"user3", "user1", "user2"}, candidates)
+}
+
+func TestReviewerSelector_RoundRobinPerTenant(t *testing.T) {
+	s := selector.NewReviewerSelector(new(mocks.LoadCounter))
+	team := &model.Team{TeamName: "team-a", Settings: model.TeamSettings{ReviewerStrategy: model.ReviewerStrategyRoundRobin}}
+	candidates := []string{"user1", "user2", "user3"}
+	acme := tenant.WithTenant(context.Background(), "acme")
+	globex := tenant.WithTenant(context.Background(), "globex")
+
+	assert.Equal(t, []string{"user1"}, s.SelectWithLoads(acme, team, candidates, 1, nil))
+	assert.Equal(t, []string{"user2"}, s.SelectWithLoads(acme, team, candidates, 1, nil))
+	assert.Equal(t, []string{"user1"}, s.SelectWithLoads(globex, team, candidates, 1, nil))
+	assert.Equal(t, []string{"user3"}, s.SelectWithLoads(acme, team, candidates, 1, nil))
+}
+
+func TestReviewerSelector_RoundRobinConcurrent(t *testing.T) {
+	ctx := context.Background()
+	s := selector.NewReviewerSelector(new(mocks.LoadCounter))
+	team := &model.Team{TeamName: "team-a", Settings: model.TeamSettings{ReviewerStrategy: model.ReviewerStrategyRoundRobin}}
+	candidates := []string{"user1", "user2", "user3"}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	picks := make(map[string]int)
+	for range 30 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			selected := s.SelectWithLoads(ctx, team, candidates, 1, nil)
+			mu.Lock()
+			picks[selected[0]]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{"user1": 10, "user2": 10, "user3": 10}, picks)
+}
+
+func TestReviewerSelector_SelectWithLoads(t *testing.T) {
+	ctx := context.Background()
+	s := selector.NewReviewerSelector(new(mocks.LoadCounter))
+	team := &model.Team{TeamName: "team-a", Settings: model.TeamSettings{ReviewerStrategy: model.ReviewerStrategyLeastLoaded}}
+	loads := map[string]int{"user1": 1, "user2": 0}
+
+	assert.Equal(t, []string{"user2"}, s.SelectWithLoads(ctx, team, []string{"user1", "user2"}, 1, loads))
+	loads["user2"] += 2
+	assert.Equal(t, []string{"user1"}, s.SelectWithLoads(ctx, team, []string{"user1", "user2"}, 1, loads))
+	assert.Empty(t, s.SelectWithLoads(ctx, team, []string{}, 1, loads))
+}
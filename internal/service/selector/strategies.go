@@ -0,0 +1,111 @@
+package selector
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/tenant"
+)
+
+type RandomStrategy struct{}
+
+func NewRandomStrategy() *RandomStrategy {
+	return &RandomStrategy{}
+}
+
+func (s *RandomStrategy) Select(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string {
+	shuffle(candidates)
+	return limit(candidates, count)
+}
+
+type RoundRobinStrategy struct {
+	mu      sync.Mutex
+	cursors map[string]int
+}
+
+func NewRoundRobinStrategy() *RoundRobinStrategy {
+	return &RoundRobinStrategy{cursors: make(map[string]int)}
+}
+
+func (s *RoundRobinStrategy) Select(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string {
+	sort.Strings(candidates)
+	count = min(count, len(candidates))
+
+	key := tenant.FromContext(ctx) + "/" + team.TeamName
+	s.mu.Lock()
+	start := s.cursors[key] % len(candidates)
+	s.cursors[key] = start + count
+	s.mu.Unlock()
+
+	selected := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, candidates[(start+i)%len(candidates)])
+	}
+	return selected
+}
+
+type LeastLoadedStrategy struct{}
+
+func NewLeastLoadedStrategy() *LeastLoadedStrategy {
+	return &LeastLoadedStrategy{}
+}
+
+func (s *LeastLoadedStrategy) Select(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string {
+	shuffle(candidates)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return loads[candidates[i]] < loads[candidates[j]]
+	})
+
+	return limit(candidates, count)
+}
+
+type WeightedStrategy struct{}
+
+func NewWeightedStrategy() *WeightedStrategy {
+	return &WeightedStrategy{}
+}
+
+func (s *WeightedStrategy) Select(ctx context.Context, team *model.Team, candidates []string, count int, loads map[string]int) []string {
+	selected := make([]string, 0, count)
+	for len(selected) < count && len(candidates) > 0 {
+		total := 0.0
+		for _, candidate := range candidates {
+			total += weight(loads[candidate])
+		}
+
+		point := rand.Float64() * total
+		picked := len(candidates) - 1
+		for i, candidate := range candidates {
+			point -= weight(loads[candidate])
+			if point < 0 {
+				picked = i
+				break
+			}
+		}
+
+		selected = append(selected, candidates[picked])
+		candidates = append(candidates[:picked], candidates[picked+1:]...)
+	}
+
+	return selected
+}
+
+func weight(load int) float64 {
+	return 1 / float64(load+1)
+}
+
+func shuffle(candidates []string) {
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+}
+
+func limit(candidates []string, count int) []string {
+	if len(candidates) > count {
+		return candidates[:count]
+	}
+	return candidates
+}
@@ -0,0 +1,82 @@
+package sla
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Calendar struct {
+	Location     *time.Location
+	WorkdayStart time.Duration
+	WorkdayEnd   time.Duration
+}
+
+func NewCalendar(timezone string, workdayStart, workdayEnd time.Duration) (Calendar, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Calendar{}, fmt.Errorf("load location %q: %w", timezone, err)
+	}
+	if workdayStart < 0 || workdayEnd > 24*time.Hour || workdayStart >= workdayEnd {
+		return Calendar{}, errors.New("workday start must be before workday end within one day")
+	}
+	return Calendar{Location: location, WorkdayStart: workdayStart, WorkdayEnd: workdayEnd}, nil
+}
+
+func (c Calendar) DueAt(assignedAt time.Time, workingHours int) *time.Time {
+	if workingHours <= 0 {
+		return nil
+	}
+
+	remaining := time.Duration(workingHours) * time.Hour
+	current := c.nextWorkingMoment(assignedAt.In(c.location()))
+	for {
+		available := c.dayEnd(current).Sub(current)
+		if remaining <= available {
+			due := current.Add(remaining).In(assignedAt.Location())
+			return &due
+		}
+		remaining -= available
+		current = c.nextWorkingMoment(c.dayStart(current.AddDate(0, 0, 1)))
+	}
+}
+
+func (c Calendar) nextWorkingMoment(t time.Time) time.Time {
+	for {
+		switch {
+		case isWeekend(t) || !t.Before(c.dayEnd(t)):
+			t = c.dayStart(t.AddDate(0, 0, 1))
+		case t.Before(c.dayStart(t)):
+			return c.dayStart(t)
+		default:
+			return t
+		}
+	}
+}
+
+func (c Calendar) dayStart(t time.Time) time.Time {
+	return atOffset(t, c.WorkdayStart)
+}
+
+func (c Calendar) dayEnd(t time.Time) time.Time {
+	if c.WorkdayEnd == 0 {
+		return atOffset(t, 24*time.Hour)
+	}
+	return atOffset(t, c.WorkdayEnd)
+}
+
+func (c Calendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+func atOffset(t time.Time, offset time.Duration) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, int(offset), t.Location())
+}
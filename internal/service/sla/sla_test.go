@@ -0,0 +1,171 @@
+package sla_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/service/sla"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_DueAt(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	calendar := sla.Calendar{Location: msk, WorkdayStart: 9 * time.Hour, WorkdayEnd: 18 * time.Hour}
+	date := func(day, hour int) time.Time {
+		return time.Date(2025, time.November, day, hour, 0, 0, 0, msk)
+	}
+
+	tests := []struct {
+		name         string
+		calendar     sla.Calendar
+		assignedAt   time.Time
+		workingHours int
+		expected     *time.Time
+	}{
+		{
+			name:         "No SLA configured",
+			calendar:     calendar,
+			assignedAt:   date(19, 10),
+			workingHours: 0,
+			expected:     nil,
+		},
+		{
+			name:         "Within the same workday",
+			calendar:     calendar,
+			assignedAt:   date(19, 10),
+			workingHours: 4,
+			expected:     ptr(date(19, 14)),
+		},
+		{
+			name:         "Ends exactly at the end of the workday",
+			calendar:     calendar,
+			assignedAt:   date(19, 10),
+			workingHours: 8,
+			expected:     ptr(date(19, 18)),
+		},
+		{
+			name:         "Crosses into the next workday",
+			calendar:     calendar,
+			assignedAt:   date(19, 16),
+			workingHours: 4,
+			expected:     ptr(date(20, 11)),
+		},
+		{
+			name:         "Assigned before the workday starts",
+			calendar:     calendar,
+			assignedAt:   date(19, 7),
+			workingHours: 2,
+			expected:     ptr(date(19, 11)),
+		},
+		{
+			name:         "Assigned exactly at the end of the workday",
+			calendar:     calendar,
+			assignedAt:   date(19, 18),
+			workingHours: 1,
+			expected:     ptr(date(20, 10)),
+		},
+		{
+			name:         "Assigned after the workday ends",
+			calendar:     calendar,
+			assignedAt:   date(19, 20),
+			workingHours: 1,
+			expected:     ptr(date(20, 10)),
+		},
+		{
+			name:         "Spans several workdays",
+			calendar:     calendar,
+			assignedAt:   date(19, 9),
+			workingHours: 20,
+			expected:     ptr(date(21, 11)),
+		},
+		{
+			name:         "Friday evening skips the weekend",
+			calendar:     calendar,
+			assignedAt:   date(21, 17),
+			workingHours: 3,
+			expected:     ptr(date(24, 11)),
+		},
+		{
+			name:         "Assigned on a weekend starts on Monday morning",
+			calendar:     calendar,
+			assignedAt:   date(22, 15),
+			workingHours: 2,
+			expected:     ptr(date(24, 11)),
+		},
+		{
+			name:         "Workday is taken in the calendar time zone",
+			calendar:     calendar,
+			assignedAt:   time.Date(2025, time.November, 19, 14, 0, 0, 0, time.UTC),
+			workingHours: 2,
+			expected:     ptr(time.Date(2025, time.November, 20, 7, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:         "Zero calendar counts whole weekdays in UTC",
+			calendar:     sla.Calendar{},
+			assignedAt:   time.Date(2025, time.November, 21, 20, 0, 0, 0, time.UTC),
+			workingHours: 8,
+			expected:     ptr(time.Date(2025, time.November, 24, 4, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.calendar.DueAt(test.assignedAt, test.workingHours))
+		})
+	}
+}
+
+func TestNewCalendar(t *testing.T) {
+	tests := []struct {
+		name          string
+		timezone      string
+		workdayStart  time.Duration
+		workdayEnd    time.Duration
+		expectedError string
+	}{
+		{
+			name:         "Success",
+			timezone:     "UTC",
+			workdayStart: 9 * time.Hour,
+			workdayEnd:   18 * time.Hour,
+		},
+		{
+			name:          "Error - unknown time zone",
+			timezone:      "Mars/Olympus",
+			workdayStart:  9 * time.Hour,
+			workdayEnd:    18 * time.Hour,
+			expectedError: `load location "Mars/Olympus": unknown time zone Mars/Olympus`,
+		},
+		{
+			name:          "Error - start after end",
+			timezone:      "UTC",
+			workdayStart:  18 * time.Hour,
+			workdayEnd:    9 * time.Hour,
+			expectedError: "workday start must be before workday end within one day",
+		},
+		{
+			name:          "Error - end past midnight",
+			timezone:      "UTC",
+			workdayStart:  9 * time.Hour,
+			workdayEnd:    25 * time.Hour,
+			expectedError: "workday start must be before workday end within one day",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calendar, err := sla.NewCalendar(test.timezone, test.workdayStart, test.workdayEnd)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, sla.Calendar{Location: time.UTC, WorkdayStart: test.workdayStart, WorkdayEnd: test.workdayEnd}, calendar)
+			}
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
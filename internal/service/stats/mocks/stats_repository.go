@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type StatsRepository struct {
+	mock.Mock
+}
+
+func (m *StatsRepository) GetUserAssignmentStats(ctx context.Context, filter model.StatsFilter) ([]model.UserAssignmentStats, error) {
+	args := m.Called(ctx, filter)
+	var stats []model.UserAssignmentStats
+	if args.Get(0) != nil {
+		stats = args.Get(0).([]model.UserAssignmentStats)
+	}
+	return stats, args.Error(1)
+}
+
+func (m *StatsRepository) GetTeamAssignmentStats(ctx context.Context, filter model.StatsFilter) ([]model.TeamAssignmentStats, error) {
+	args := m.Called(ctx, filter)
+	var stats []model.TeamAssignmentStats
+	if args.Get(0) != nil {
+		stats = args.Get(0).([]model.TeamAssignmentStats)
+	}
+	return stats, args.Error(1)
+}
+
+func (m *StatsRepository) GetTeamPullRequestStats(ctx context.Context, filter model.StatsFilter, staleBefore time.Time) ([]model.TeamPullRequestStats, error) {
+	args := m.Called(ctx, filter, staleBefore)
+	var stats []model.TeamPullRequestStats
+	if args.Get(0) != nil {
+		stats = args.Get(0).([]model.TeamPullRequestStats)
+	}
+	return stats, args.Error(1)
+}
+
+func (m *StatsRepository) GetAuthorPullRequestStats(ctx context.Context, filter model.StatsFilter, staleBefore time.Time) ([]model.AuthorPullRequestStats, error) {
+	args := m.Called(ctx, filter, staleBefore)
+	var stats []model.AuthorPullRequestStats
+	if args.Get(0) != nil {
+		stats = args.Get(0).([]model.AuthorPullRequestStats)
+	}
+	return stats, args.Error(1)
+}
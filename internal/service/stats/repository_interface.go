@@ -0,0 +1,15 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type StatsRepository interface {
+	GetUserAssignmentStats(ctx context.Context, filter model.StatsFilter) ([]model.UserAssignmentStats, error)
+	GetTeamAssignmentStats(ctx context.Context, filter model.StatsFilter) ([]model.TeamAssignmentStats, error)
+	GetTeamPullRequestStats(ctx context.Context, filter model.StatsFilter, staleBefore time.Time) ([]model.TeamPullRequestStats, error)
+	GetAuthorPullRequestStats(ctx context.Context, filter model.StatsFilter, staleBefore time.Time) ([]model.AuthorPullRequestStats, error)
+}
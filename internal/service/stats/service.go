@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+const defaultOpenThreshold = 7 * 24 * time.Hour
+
+type StatsService struct {
+	statsRepository StatsRepository
+}
+
+func NewStatsService(statsRepository StatsRepository) *StatsService {
+	return &StatsService{statsRepository: statsRepository}
+}
+
+func (s *StatsService) GetAssignmentStats(ctx context.Context, filter model.StatsFilter) (*model.AssignmentStatsReport, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	users, err := s.statsRepository.GetUserAssignmentStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get user assignment stats: %w", err)
+	}
+
+	teams, err := s.statsRepository.GetTeamAssignmentStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get team assignment stats: %w", err)
+	}
+
+	return &model.AssignmentStatsReport{Users: users, Teams: teams}, nil
+}
+
+func (s *StatsService) GetPullRequestStats(ctx context.Context, filter model.PullRequestStatsFilter) (*model.PullRequestStatsReport, error) {
+	if err := validateFilter(filter.StatsFilter); err != nil {
+		return nil, err
+	}
+	if filter.OpenThreshold < 0 {
+		return nil, model.ErrInvalidThreshold
+	}
+	if filter.OpenThreshold == 0 {
+		filter.OpenThreshold = defaultOpenThreshold
+	}
+	staleBefore := time.Now().Add(-filter.OpenThreshold)
+
+	teams, err := s.statsRepository.GetTeamPullRequestStats(ctx, filter.StatsFilter, staleBefore)
+	if err != nil {
+		return nil, fmt.Errorf("get team pull request stats: %w", err)
+	}
+
+	authors, err := s.statsRepository.GetAuthorPullRequestStats(ctx, filter.StatsFilter, staleBefore)
+	if err != nil {
+		return nil, fmt.Errorf("get author pull request stats: %w", err)
+	}
+
+	staleTotal := 0
+	for _, team := range teams {
+		staleTotal += team.StaleOpenCount
+	}
+
+	return &model.PullRequestStatsReport{
+		OpenThreshold:  filter.OpenThreshold,
+		StaleOpenTotal: staleTotal,
+		Teams:          teams,
+		Authors:        authors,
+	}, nil
+}
+
+func validateFilter(filter model.StatsFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return model.ErrInvalidTimeRange
+	}
+	return nil
+}
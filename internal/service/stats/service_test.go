@@ -0,0 +1,228 @@
+package stats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/stats"
+	"github.com/avito/internship/pr-service/internal/service/stats/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStatsService_GetAssignmentStats(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	users := []model.UserAssignmentStats{{UserID: "u1", TeamName: "team-a", AssignmentStats: model.AssignmentStats{Total: 3, Open: 1}}}
+	teams := []model.TeamAssignmentStats{{TeamName: "team-a", AssignmentStats: model.AssignmentStats{Total: 3, Open: 1}}}
+	reassignedUsers := []model.UserAssignmentStats{
+		{UserID: "u1", TeamName: "team-a", AssignmentStats: model.AssignmentStats{Total: 1, Reassigned: 1}},
+		{UserID: "u2", TeamName: "team-a", AssignmentStats: model.AssignmentStats{Total: 1, Open: 1}},
+	}
+	reassignedTeams := []model.TeamAssignmentStats{{TeamName: "team-a", AssignmentStats: model.AssignmentStats{Total: 2, Open: 1, Reassigned: 1}}}
+
+	tests := []struct {
+		name          string
+		filter        model.StatsFilter
+		setupMocks    func(*mocks.StatsRepository)
+		expected      *model.AssignmentStatsReport
+		expectedErr   error
+		expectedError string
+	}{
+		{
+			name:   "Success",
+			filter: model.StatsFilter{From: &from, To: &to},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetUserAssignmentStats", ctx, model.StatsFilter{From: &from, To: &to}).Return(users, nil).Once()
+				statsRepository.On("GetTeamAssignmentStats", ctx, model.StatsFilter{From: &from, To: &to}).Return(teams, nil).Once()
+			},
+			expected: &model.AssignmentStatsReport{Users: users, Teams: teams},
+		},
+		{
+			name:   "Success - open-ended range",
+			filter: model.StatsFilter{From: &from},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetUserAssignmentStats", ctx, model.StatsFilter{From: &from}).Return([]model.UserAssignmentStats{}, nil).Once()
+				statsRepository.On("GetTeamAssignmentStats", ctx, model.StatsFilter{From: &from}).Return([]model.TeamAssignmentStats{}, nil).Once()
+			},
+			expected: &model.AssignmentStatsReport{Users: []model.UserAssignmentStats{}, Teams: []model.TeamAssignmentStats{}},
+		},
+		{
+			name:   "Success - reassigned reviewer keeps the historical assignment",
+			filter: model.StatsFilter{From: &from, To: &to},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetUserAssignmentStats", ctx, model.StatsFilter{From: &from, To: &to}).Return(reassignedUsers, nil).Once()
+				statsRepository.On("GetTeamAssignmentStats", ctx, model.StatsFilter{From: &from, To: &to}).Return(reassignedTeams, nil).Once()
+			},
+			expected: &model.AssignmentStatsReport{Users: reassignedUsers, Teams: reassignedTeams},
+		},
+		{
+			name:          "Error - from after to",
+			filter:        model.StatsFilter{From: &to, To: &from},
+			setupMocks:    func(statsRepository *mocks.StatsRepository) {},
+			expectedErr:   model.ErrInvalidTimeRange,
+			expectedError: "invalid time range",
+		},
+		{
+			name:          "Error - empty range",
+			filter:        model.StatsFilter{From: &from, To: &from},
+			setupMocks:    func(statsRepository *mocks.StatsRepository) {},
+			expectedErr:   model.ErrInvalidTimeRange,
+			expectedError: "invalid time range",
+		},
+		{
+			name:   "Error - user stats fail",
+			filter: model.StatsFilter{},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetUserAssignmentStats", ctx, model.StatsFilter{}).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "get user assignment stats: db error",
+		},
+		{
+			name:   "Error - team stats fail",
+			filter: model.StatsFilter{},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetUserAssignmentStats", ctx, model.StatsFilter{}).Return(users, nil).Once()
+				statsRepository.On("GetTeamAssignmentStats", ctx, model.StatsFilter{}).Return(nil, context.DeadlineExceeded).Once()
+			},
+			expectedErr:   context.DeadlineExceeded,
+			expectedError: "get team assignment stats: context deadline exceeded",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statsRepository := new(mocks.StatsRepository)
+			test.setupMocks(statsRepository)
+
+			s := stats.NewStatsService(statsRepository)
+			report, err := s.GetAssignmentStats(ctx, test.filter)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, report)
+				if test.expectedErr != nil {
+					assert.ErrorIs(t, err, test.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, report)
+			}
+			statsRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStatsService_GetPullRequestStats(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	staleAround := func(threshold time.Duration) any {
+		return mock.MatchedBy(func(staleBefore time.Time) bool {
+			elapsed := time.Since(staleBefore) - threshold
+			return elapsed >= 0 && elapsed < time.Minute
+		})
+	}
+
+	tests := []struct {
+		name          string
+		filter        model.PullRequestStatsFilter
+		setupMocks    func(*mocks.StatsRepository)
+		expected      *model.PullRequestStatsReport
+		expectedErr   error
+		expectedError string
+	}{
+		{
+			name:   "Success - default threshold and stale total",
+			filter: model.PullRequestStatsFilter{},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetTeamPullRequestStats", ctx, model.StatsFilter{}, staleAround(7*24*time.Hour)).Return([]model.TeamPullRequestStats{
+					{TeamName: "team-a", PullRequestStats: model.PullRequestStats{StaleOpenCount: 2}},
+					{TeamName: "team-b", PullRequestStats: model.PullRequestStats{StaleOpenCount: 1}},
+				}, nil).Once()
+				statsRepository.On("GetAuthorPullRequestStats", ctx, model.StatsFilter{}, staleAround(7*24*time.Hour)).Return([]model.AuthorPullRequestStats{}, nil).Once()
+			},
+			expected: &model.PullRequestStatsReport{
+				OpenThreshold:  7 * 24 * time.Hour,
+				StaleOpenTotal: 3,
+				Teams: []model.TeamPullRequestStats{
+					{TeamName: "team-a", PullRequestStats: model.PullRequestStats{StaleOpenCount: 2}},
+					{TeamName: "team-b", PullRequestStats: model.PullRequestStats{StaleOpenCount: 1}},
+				},
+				Authors: []model.AuthorPullRequestStats{},
+			},
+		},
+		{
+			name:   "Success - custom threshold and range",
+			filter: model.PullRequestStatsFilter{StatsFilter: model.StatsFilter{From: &from, To: &to}, OpenThreshold: time.Hour},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetTeamPullRequestStats", ctx, model.StatsFilter{From: &from, To: &to}, staleAround(time.Hour)).Return([]model.TeamPullRequestStats{}, nil).Once()
+				statsRepository.On("GetAuthorPullRequestStats", ctx, model.StatsFilter{From: &from, To: &to}, staleAround(time.Hour)).Return([]model.AuthorPullRequestStats{{AuthorID: "u1"}}, nil).Once()
+			},
+			expected: &model.PullRequestStatsReport{
+				OpenThreshold: time.Hour,
+				Teams:         []model.TeamPullRequestStats{},
+				Authors:       []model.AuthorPullRequestStats{{AuthorID: "u1"}},
+			},
+		},
+		{
+			name:          "Error - from after to",
+			filter:        model.PullRequestStatsFilter{StatsFilter: model.StatsFilter{From: &to, To: &from}},
+			setupMocks:    func(statsRepository *mocks.StatsRepository) {},
+			expectedErr:   model.ErrInvalidTimeRange,
+			expectedError: "invalid time range",
+		},
+		{
+			name:          "Error - negative threshold",
+			filter:        model.PullRequestStatsFilter{OpenThreshold: -time.Hour},
+			setupMocks:    func(statsRepository *mocks.StatsRepository) {},
+			expectedErr:   model.ErrInvalidThreshold,
+			expectedError: "invalid open threshold",
+		},
+		{
+			name:   "Error - team stats fail",
+			filter: model.PullRequestStatsFilter{OpenThreshold: time.Hour},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetTeamPullRequestStats", ctx, model.StatsFilter{}, staleAround(time.Hour)).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "get team pull request stats: db error",
+		},
+		{
+			name:   "Error - author stats fail",
+			filter: model.PullRequestStatsFilter{OpenThreshold: time.Hour},
+			setupMocks: func(statsRepository *mocks.StatsRepository) {
+				statsRepository.On("GetTeamPullRequestStats", ctx, model.StatsFilter{}, staleAround(time.Hour)).Return([]model.TeamPullRequestStats{}, nil).Once()
+				statsRepository.On("GetAuthorPullRequestStats", ctx, model.StatsFilter{}, staleAround(time.Hour)).Return(nil, context.Canceled).Once()
+			},
+			expectedErr:   context.Canceled,
+			expectedError: "get author pull request stats: context canceled",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statsRepository := new(mocks.StatsRepository)
+			test.setupMocks(statsRepository)
+
+			s := stats.NewStatsService(statsRepository)
+			report, err := s.GetPullRequestStats(ctx, test.filter)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, report)
+				if test.expectedErr != nil {
+					assert.ErrorIs(t, err, test.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, report)
+			}
+			statsRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditRecorder struct {
+	mock.Mock
+}
+
+func (m *AuditRecorder) Record(ctx context.Context, event model.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReviewReassigner struct {
+	mock.Mock
+}
+
+func (m *ReviewReassigner) ReassignReviews(ctx context.Context, teamName string, userIDs []string) (*model.ReassignmentReport, error) {
+	args := m.Called(ctx, teamName, userIDs)
+	var report *model.ReassignmentReport
+	if args.Get(0) != nil {
+		report = args.Get(0).(*model.ReassignmentReport)
+	}
+	return report, args.Error(1)
+}
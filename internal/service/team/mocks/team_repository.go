@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type TeamRepository struct {
+	mock.Mock
+}
+
+func (m *TeamRepository) CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error) {
+	args := m.Called(ctx, team)
+	var createdTeam *model.Team
+	if args.Get(0) != nil {
+		createdTeam = args.Get(0).(*model.Team)
+	}
+	return createdTeam, args.Error(1)
+}
+
+func (m *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*model.Team, error) {
+	args := m.Called(ctx, teamName)
+	var team *model.Team
+	if args.Get(0) != nil {
+		team = args.Get(0).(*model.Team)
+	}
+	return team, args.Error(1)
+}
+
+func (m *TeamRepository) LockSettings(ctx context.Context, teamName string) (*model.TeamSettings, error) {
+	args := m.Called(ctx, teamName)
+	var settings *model.TeamSettings
+	if args.Get(0) != nil {
+		settings = args.Get(0).(*model.TeamSettings)
+	}
+	return settings, args.Error(1)
+}
+
+func (m *TeamRepository) UpdateSettings(ctx context.Context, teamName string, settings model.TeamSettings) error {
+	args := m.Called(ctx, teamName, settings)
+	return args.Error(0)
+}
+
+func (m *TeamRepository) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) ([]model.User, error) {
+	args := m.Called(ctx, teamName, userIDs)
+	var users []model.User
+	if args.Get(0) != nil {
+		users = args.Get(0).([]model.User)
+	}
+	return users, args.Error(1)
+}
@@ -0,0 +1,12 @@
+package mocks
+
+import "context"
+
+type TxManager struct {
+	Calls int
+}
+
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	return fn(ctx)
+}
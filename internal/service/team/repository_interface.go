@@ -9,4 +9,19 @@ import (
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error)
 	GetTeamByName(ctx context.Context, teamName string) (*model.Team, error)
+	LockSettings(ctx context.Context, teamName string) (*model.TeamSettings, error)
+	UpdateSettings(ctx context.Context, teamName string, settings model.TeamSettings) error
+	DeactivateUsers(ctx context.Context, teamName string, userIDs []string) ([]model.User, error)
+}
+
+type ReviewReassigner interface {
+	ReassignReviews(ctx context.Context, teamName string, userIDs []string) (*model.ReassignmentReport, error)
+}
+
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, event model.AuditEvent) error
 }
@@ -2,24 +2,171 @@ package team
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/avito/internship/pr-service/internal/model"
 )
 
 type TeamService struct {
-	taemRepository TeamRepository
+	taemRepository   TeamRepository
+	reviewReassigner ReviewReassigner
+	txManager        TxManager
+	auditRecorder    AuditRecorder
 }
 
-func NewTeamService(repository TeamRepository) *TeamService {
+func NewTeamService(repository TeamRepository, reviewReassigner ReviewReassigner, txManager TxManager, auditRecorder AuditRecorder) *TeamService {
 	return &TeamService{
-		taemRepository: repository,
+		taemRepository:   repository,
+		reviewReassigner: reviewReassigner,
+		txManager:        txManager,
+		auditRecorder:    auditRecorder,
 	}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, team *model.Team) (*model.Team, error) {
-	return s.taemRepository.CreateTeam(ctx, team)
+	settings, err := normalizeSettings(team.Settings)
+	if err != nil {
+		return nil, err
+	}
+	team.Settings = settings
+
+	var createdTeam *model.Team
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		createdTeam, err = s.taemRepository.CreateTeam(ctx, team)
+		if err != nil {
+			return err
+		}
+
+		err = s.auditRecorder.Record(ctx, model.AuditEvent{
+			Action:     model.AuditActionTeamCreate,
+			TargetType: model.AuditTargetTeam,
+			TargetID:   createdTeam.TeamName,
+			TeamName:   createdTeam.TeamName,
+			After:      createdTeam,
+		})
+		if err != nil {
+			return fmt.Errorf("record audit event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdTeam, nil
 }
 
 func (s *TeamService) GetTeamByName(ctx context.Context, teamName string) (*model.Team, error) {
 	return s.taemRepository.GetTeamByName(ctx, teamName)
 }
+
+func (s *TeamService) UpdateSettings(ctx context.Context, teamName string, update model.TeamSettingsUpdate) (*model.Team, error) {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.taemRepository.LockSettings(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		settings, err := normalizeSettings(update.Apply(*current))
+		if err != nil {
+			return err
+		}
+		if err := s.taemRepository.UpdateSettings(ctx, teamName, settings); err != nil {
+			return err
+		}
+
+		err = s.auditRecorder.Record(ctx, model.AuditEvent{
+			Action:     model.AuditActionSettings,
+			TargetType: model.AuditTargetTeam,
+			TargetID:   teamName,
+			TeamName:   teamName,
+			Before:     current,
+			After:      settings,
+		})
+		if err != nil {
+			return fmt.Errorf("record audit event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.taemRepository.GetTeamByName(ctx, teamName)
+}
+
+func (s *TeamService) DeactivateUsers(ctx context.Context, teamName string, userIDs []string) (*model.TeamDeactivation, error) {
+	userIDs = slices.Compact(slices.Sorted(slices.Values(userIDs)))
+	if len(userIDs) == 0 {
+		return nil, model.ErrNoUsersToDeactivate
+	}
+
+	deactivation := &model.TeamDeactivation{TeamName: teamName}
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := s.taemRepository.GetTeamByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		users, err := s.taemRepository.DeactivateUsers(ctx, teamName, userIDs)
+		if err != nil {
+			return err
+		}
+		if len(users) != len(userIDs) {
+			return model.ErrUserNotInTeam
+		}
+		deactivation.Users = users
+
+		for _, user := range users {
+			before := user
+			if i := slices.IndexFunc(team.Users, func(u model.User) bool { return u.UserID == user.UserID }); i >= 0 {
+				before = team.Users[i]
+			}
+			err = s.auditRecorder.Record(ctx, model.AuditEvent{
+				Action:     model.AuditActionSetActive,
+				TargetType: model.AuditTargetUser,
+				TargetID:   user.UserID,
+				UserID:     user.UserID,
+				TeamName:   teamName,
+				Before:     before,
+				After:      user,
+			})
+			if err != nil {
+				return fmt.Errorf("record audit event: %w", err)
+			}
+		}
+
+		report, err := s.reviewReassigner.ReassignReviews(ctx, teamName, userIDs)
+		if err != nil {
+			return fmt.Errorf("reassign reviews: %w", err)
+		}
+		deactivation.Reassignment = report
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deactivation, nil
+}
+
+func normalizeSettings(settings model.TeamSettings) (model.TeamSettings, error) {
+	if settings.ReviewerStrategy == "" {
+		settings.ReviewerStrategy = model.ReviewerStrategyLeastLoaded
+	}
+	if !settings.ReviewerStrategy.IsValid() {
+		return settings, model.ErrInvalidStrategy
+	}
+	if settings.MaxReviewers == 0 {
+		settings.MaxReviewers = max(model.DefaultMaxReviewers, settings.MinReviewers)
+	}
+	if settings.MinReviewers < 0 || settings.MinReviewers > settings.MaxReviewers || settings.MaxReviewers > model.ReviewersLimit {
+		return settings, model.ErrInvalidReviewerCount
+	}
+	if settings.MergePolicy.MinApprovals < 0 || settings.MergePolicy.MinApprovals > settings.MaxReviewers {
+		return settings, model.ErrInvalidMergePolicy
+	}
+	if settings.ReviewSLAHours < 0 {
+		return settings, model.ErrInvalidReviewSLA
+	}
+	return settings, nil
+}
@@ -0,0 +1,293 @@
+package team_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/team"
+	"github.com/avito/internship/pr-service/internal/service/team/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTeamService_CreateTeam(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		settings         model.TeamSettings
+		expectedSettings model.TeamSettings
+		expectedError    string
+	}{
+		{
+			name:             "Success - defaults applied",
+			settings:         model.TeamSettings{},
+			expectedSettings: model.TeamSettings{ReviewerStrategy: model.ReviewerStrategyLeastLoaded, MinReviewers: 0, MaxReviewers: 2},
+		},
+		{
+			name:             "Success - min above default max raises max",
+			settings:         model.TeamSettings{ReviewerStrategy: model.ReviewerStrategyRandom, MinReviewers: 3},
+			expectedSettings: model.TeamSettings{ReviewerStrategy: model.ReviewerStrategyRandom, MinReviewers: 3, MaxReviewers: 3},
+		},
+		{
+			name:          "Error - unknown strategy",
+			settings:      model.TeamSettings{ReviewerStrategy: "ALPHABETICAL"},
+			expectedError: "unknown reviewer strategy",
+		},
+		{
+			name:          "Error - min greater than max",
+			settings:      model.TeamSettings{MinReviewers: 3, MaxReviewers: 1},
+			expectedError: "invalid min/max reviewers count",
+		},
+		{
+			name:          "Error - max above limit",
+			settings:      model.TeamSettings{MaxReviewers: model.ReviewersLimit + 1},
+			expectedError: "invalid min/max reviewers count",
+		},
+		{
+			name:          "Error - min approvals above max reviewers",
+			settings:      model.TeamSettings{MaxReviewers: 2, MergePolicy: model.MergePolicy{MinApprovals: 3}},
+			expectedError: "min_approvals must be between 0 and max_reviewers",
+		},
+		{
+			name:          "Error - negative review SLA",
+			settings:      model.TeamSettings{ReviewSLAHours: -1},
+			expectedError: "review_sla_hours must not be negative",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			teamRepository := new(mocks.TeamRepository)
+			newTeam := &model.Team{TeamName: "team-a", Settings: test.settings}
+			auditRecorder := new(mocks.AuditRecorder)
+			if test.expectedError == "" {
+				teamRepository.On("CreateTeam", ctx, newTeam).Return(newTeam, nil).Once()
+				auditRecorder.On("Record", ctx, model.AuditEvent{
+					Action:     model.AuditActionTeamCreate,
+					TargetType: model.AuditTargetTeam,
+					TargetID:   "team-a",
+					TeamName:   "team-a",
+					After:      newTeam,
+				}).Return(nil).Once()
+			}
+
+			s := team.NewTeamService(teamRepository, nil, new(mocks.TxManager), auditRecorder)
+			createdTeam, err := s.CreateTeam(ctx, newTeam)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedSettings, createdTeam.Settings)
+			}
+			teamRepository.AssertExpectations(t)
+			auditRecorder.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	current := &model.TeamSettings{
+		ReviewerStrategy: model.ReviewerStrategyRandom,
+		MinReviewers:     1,
+		MaxReviewers:     3,
+		MergePolicy:      model.MergePolicy{MinApprovals: 2, BlockOnChangesRequested: true},
+		ReviewSLAHours:   24,
+	}
+	one := 1
+	disabled := false
+	tooMany := 4
+
+	tests := []struct {
+		name             string
+		update           model.TeamSettingsUpdate
+		lockError        error
+		expectedSettings model.TeamSettings
+		expectedError    string
+	}{
+		{
+			name:   "Success - only passed fields change",
+			update: model.TeamSettingsUpdate{MergePolicy: model.MergePolicyUpdate{MinApprovals: &one}},
+			expectedSettings: model.TeamSettings{
+				ReviewerStrategy: model.ReviewerStrategyRandom,
+				MinReviewers:     1,
+				MaxReviewers:     3,
+				MergePolicy:      model.MergePolicy{MinApprovals: 1, BlockOnChangesRequested: true},
+				ReviewSLAHours:   24,
+			},
+		},
+		{
+			name:   "Success - false overrides true",
+			update: model.TeamSettingsUpdate{MergePolicy: model.MergePolicyUpdate{BlockOnChangesRequested: &disabled}},
+			expectedSettings: model.TeamSettings{
+				ReviewerStrategy: model.ReviewerStrategyRandom,
+				MinReviewers:     1,
+				MaxReviewers:     3,
+				MergePolicy:      model.MergePolicy{MinApprovals: 2},
+				ReviewSLAHours:   24,
+			},
+		},
+		{
+			name:          "Error - merged settings are validated",
+			update:        model.TeamSettingsUpdate{MinReviewers: &tooMany},
+			expectedError: "invalid min/max reviewers count",
+		},
+		{
+			name:          "Error - team not found",
+			lockError:     model.ErrTeamNotFound,
+			expectedError: model.ErrTeamNotFound.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			teamRepository := new(mocks.TeamRepository)
+			if test.lockError != nil {
+				teamRepository.On("LockSettings", ctx, "team-a").Return(nil, test.lockError).Once()
+			} else {
+				teamRepository.On("LockSettings", ctx, "team-a").Return(current, nil).Once()
+			}
+			auditRecorder := new(mocks.AuditRecorder)
+			if test.expectedError == "" {
+				teamRepository.On("UpdateSettings", ctx, "team-a", test.expectedSettings).Return(nil).Once()
+				teamRepository.On("GetTeamByName", ctx, "team-a").Return(&model.Team{TeamName: "team-a", Settings: test.expectedSettings}, nil).Once()
+				auditRecorder.On("Record", ctx, model.AuditEvent{
+					Action:     model.AuditActionSettings,
+					TargetType: model.AuditTargetTeam,
+					TargetID:   "team-a",
+					TeamName:   "team-a",
+					Before:     current,
+					After:      test.expectedSettings,
+				}).Return(nil).Once()
+			}
+
+			s := team.NewTeamService(teamRepository, nil, new(mocks.TxManager), auditRecorder)
+			updatedTeam, err := s.UpdateSettings(ctx, "team-a", test.update)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedSettings, updatedTeam.Settings)
+			}
+			teamRepository.AssertExpectations(t)
+			auditRecorder.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_DeactivateUsers(t *testing.T) {
+	ctx := context.Background()
+	users := []model.User{
+		{UserID: "user1", TeamName: "team-a"},
+		{UserID: "user2", TeamName: "team-a"},
+	}
+	teamA := &model.Team{
+		TeamName: "team-a",
+		Users: []model.User{
+			{UserID: "user1", TeamName: "team-a", IsActive: true},
+			{UserID: "user2", TeamName: "team-a", IsActive: true},
+			{UserID: "user3", TeamName: "team-a", IsActive: true},
+		},
+	}
+	setActiveAudit := func(i int) model.AuditEvent {
+		return model.AuditEvent{
+			Action:     model.AuditActionSetActive,
+			TargetType: model.AuditTargetUser,
+			TargetID:   users[i].UserID,
+			UserID:     users[i].UserID,
+			TeamName:   "team-a",
+			Before:     teamA.Users[i],
+			After:      users[i],
+		}
+	}
+	report := &model.ReassignmentReport{
+		Reassigned: []model.ReviewReassignment{{PullRequestID: "pr1", OldReviewerID: "user1", NewReviewerID: "user3"}},
+		Failed:     []model.ReassignmentFailure{},
+	}
+
+	tests := []struct {
+		name          string
+		userIDs       []string
+		setupMocks    func(*mocks.TeamRepository, *mocks.ReviewReassigner, *mocks.AuditRecorder)
+		expected      *model.TeamDeactivation
+		expectedError string
+	}{
+		{
+			name:    "Success",
+			userIDs: []string{"user2", "user1", "user2"},
+			setupMocks: func(teamRepository *mocks.TeamRepository, reviewReassigner *mocks.ReviewReassigner, auditRecorder *mocks.AuditRecorder) {
+				teamRepository.On("GetTeamByName", ctx, "team-a").Return(teamA, nil).Once()
+				teamRepository.On("DeactivateUsers", ctx, "team-a", []string{"user1", "user2"}).Return(users, nil).Once()
+				auditRecorder.On("Record", ctx, setActiveAudit(0)).Return(nil).Once()
+				auditRecorder.On("Record", ctx, setActiveAudit(1)).Return(nil).Once()
+				reviewReassigner.On("ReassignReviews", ctx, "team-a", []string{"user1", "user2"}).Return(report, nil).Once()
+			},
+			expected: &model.TeamDeactivation{TeamName: "team-a", Users: users, Reassignment: report},
+		},
+		{
+			name:    "Error - audit fails",
+			userIDs: []string{"user1", "user2"},
+			setupMocks: func(teamRepository *mocks.TeamRepository, reviewReassigner *mocks.ReviewReassigner, auditRecorder *mocks.AuditRecorder) {
+				teamRepository.On("GetTeamByName", ctx, "team-a").Return(teamA, nil).Once()
+				teamRepository.On("DeactivateUsers", ctx, "team-a", []string{"user1", "user2"}).Return(users, nil).Once()
+				auditRecorder.On("Record", ctx, setActiveAudit(0)).Return(errors.New("db error")).Once()
+			},
+			expectedError: "record audit event: db error",
+		},
+		{
+			name:    "Error - empty user list",
+			userIDs: nil,
+			setupMocks: func(teamRepository *mocks.TeamRepository, reviewReassigner *mocks.ReviewReassigner, auditRecorder *mocks.AuditRecorder) {
+			},
+			expectedError: "user_ids must not be empty",
+		},
+		{
+			name:    "Error - user from another team",
+			userIDs: []string{"user1", "user2", "stranger"},
+			setupMocks: func(teamRepository *mocks.TeamRepository, reviewReassigner *mocks.ReviewReassigner, auditRecorder *mocks.AuditRecorder) {
+				teamRepository.On("GetTeamByName", ctx, "team-a").Return(teamA, nil).Once()
+				teamRepository.On("DeactivateUsers", ctx, "team-a", mock.Anything).Return(users, nil).Once()
+			},
+			expectedError: "user not found in team",
+		},
+		{
+			name:    "Error - reassignment fails",
+			userIDs: []string{"user1", "user2"},
+			setupMocks: func(teamRepository *mocks.TeamRepository, reviewReassigner *mocks.ReviewReassigner, auditRecorder *mocks.AuditRecorder) {
+				teamRepository.On("GetTeamByName", ctx, "team-a").Return(teamA, nil).Once()
+				teamRepository.On("DeactivateUsers", ctx, "team-a", []string{"user1", "user2"}).Return(users, nil).Once()
+				auditRecorder.On("Record", ctx, mock.AnythingOfType("model.AuditEvent")).Return(nil).Twice()
+				reviewReassigner.On("ReassignReviews", ctx, "team-a", []string{"user1", "user2"}).Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "reassign reviews: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			teamRepository := new(mocks.TeamRepository)
+			reviewReassigner := new(mocks.ReviewReassigner)
+			auditRecorder := new(mocks.AuditRecorder)
+			test.setupMocks(teamRepository, reviewReassigner, auditRecorder)
+
+			s := team.NewTeamService(teamRepository, reviewReassigner, new(mocks.TxManager), auditRecorder)
+			deactivation, err := s.DeactivateUsers(ctx, "team-a", test.userIDs)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, deactivation)
+			}
+
+			teamRepository.AssertExpectations(t)
+			reviewReassigner.AssertExpectations(t)
+			auditRecorder.AssertExpectations(t)
+		})
+	}
+}
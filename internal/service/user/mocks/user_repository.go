@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserRepository struct {
+	mock.Mock
+}
+
+func (m *UserRepository) UpdateStatus(ctx context.Context, userId string, isActive bool) (*model.User, error) {
+	args := m.Called(ctx, userId, isActive)
+	var user *model.User
+	if args.Get(0) != nil {
+		user = args.Get(0).(*model.User)
+	}
+	return user, args.Error(1)
+}
+
+func (m *UserRepository) GetUserByID(ctx context.Context, userId string) (*model.User, error) {
+	args := m.Called(ctx, userId)
+	var user *model.User
+	if args.Get(0) != nil {
+		user = args.Get(0).(*model.User)
+	}
+	return user, args.Error(1)
+}
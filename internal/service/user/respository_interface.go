@@ -10,3 +10,15 @@ type UserRepository interface {
 	UpdateStatus(ctx context.Context, userId string, isActive bool) (*model.User, error)
 	GetUserByID(ctx context.Context, userId string) (*model.User, error)
 }
+
+type ReviewReassigner interface {
+	ReassignUserReviews(ctx context.Context, userID string) (*model.ReassignmentReport, error)
+}
+
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, event model.AuditEvent) error
+}
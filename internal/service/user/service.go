@@ -2,20 +2,70 @@ package user
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/avito/internship/pr-service/internal/model"
 )
 
 type UserService struct {
-	userRepository UserRepository
+	userRepository   UserRepository
+	reviewReassigner ReviewReassigner
+	txManager        TxManager
+	auditRecorder    AuditRecorder
 }
 
-func NewUserService(repostiroy UserRepository) *UserService {
-	return &UserService{userRepository: repostiroy}
+func NewUserService(repostiroy UserRepository, reviewReassigner ReviewReassigner, txManager TxManager, auditRecorder AuditRecorder) *UserService {
+	return &UserService{
+		userRepository:   repostiroy,
+		reviewReassigner: reviewReassigner,
+		txManager:        txManager,
+		auditRecorder:    auditRecorder,
+	}
 }
 
-func (service *UserService) SetActiveStatus(ctx context.Context, userId string, isActive bool) (*model.User, error) {
-	return service.userRepository.UpdateStatus(ctx, userId, isActive)
+func (service *UserService) SetActiveStatus(ctx context.Context, userId string, isActive bool) (*model.UserStatusChange, error) {
+	var change model.UserStatusChange
+	err := service.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := service.userRepository.GetUserByID(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		user, err := service.userRepository.UpdateStatus(ctx, userId, isActive)
+		if err != nil {
+			return err
+		}
+		change.User = user
+
+		err = service.auditRecorder.Record(ctx, model.AuditEvent{
+			Action:     model.AuditActionSetActive,
+			TargetType: model.AuditTargetUser,
+			TargetID:   userId,
+			UserID:     userId,
+			TeamName:   user.TeamName,
+			Before:     before,
+			After:      user,
+		})
+		if err != nil {
+			return fmt.Errorf("record audit event: %w", err)
+		}
+
+		if isActive {
+			return nil
+		}
+
+		report, err := service.reviewReassigner.ReassignUserReviews(ctx, userId)
+		if err != nil {
+			return fmt.Errorf("reassign reviews: %w", err)
+		}
+		change.Reassignment = report
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, nil
 }
 
 func (service *UserService) GetUserByID(ctx context.Context, userId string) (*model.User, error) {
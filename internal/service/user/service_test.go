@@ -0,0 +1,108 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/user"
+	"github.com/avito/internship/pr-service/internal/service/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserService_SetActiveStatus(t *testing.T) {
+	ctx := context.Background()
+	activeUser := &model.User{UserID: "user1", TeamName: "team-a", IsActive: true}
+	inactiveUser := &model.User{UserID: "user1", TeamName: "team-a", IsActive: false}
+	report := &model.ReassignmentReport{
+		Reassigned: []model.ReviewReassignment{{PullRequestID: "pr1", OldReviewerID: "user1", NewReviewerID: "user2"}},
+		Failed:     []model.ReassignmentFailure{{PullRequestID: "pr2", ReviewerID: "user1", Reason: "no active replacement candidate in team"}},
+	}
+
+	tests := []struct {
+		name           string
+		isActive       bool
+		setupMocks     func(*mocks.UserRepository, *mocks.ReviewReassigner)
+		auditErr       error
+		expectedChange *model.UserStatusChange
+		expectedError  string
+	}{
+		{
+			name:     "Success - activation does not reassign",
+			isActive: true,
+			setupMocks: func(userRepository *mocks.UserRepository, reviewReassigner *mocks.ReviewReassigner) {
+				userRepository.On("GetUserByID", ctx, "user1").Return(inactiveUser, nil).Once()
+				userRepository.On("UpdateStatus", ctx, "user1", true).Return(activeUser, nil).Once()
+			},
+			expectedChange: &model.UserStatusChange{User: activeUser},
+		},
+		{
+			name:     "Success - deactivation reassigns open reviews",
+			isActive: false,
+			setupMocks: func(userRepository *mocks.UserRepository, reviewReassigner *mocks.ReviewReassigner) {
+				userRepository.On("GetUserByID", ctx, "user1").Return(activeUser, nil).Once()
+				userRepository.On("UpdateStatus", ctx, "user1", false).Return(inactiveUser, nil).Once()
+				reviewReassigner.On("ReassignUserReviews", ctx, "user1").Return(report, nil).Once()
+			},
+			expectedChange: &model.UserStatusChange{User: inactiveUser, Reassignment: report},
+		},
+		{
+			name:     "Error - user not found",
+			isActive: false,
+			setupMocks: func(userRepository *mocks.UserRepository, reviewReassigner *mocks.ReviewReassigner) {
+				userRepository.On("GetUserByID", ctx, "user1").Return(nil, model.ErrUserNotFound).Once()
+			},
+			expectedError: "user not found",
+		},
+		{
+			name:     "Error - reassignment fails",
+			isActive: false,
+			setupMocks: func(userRepository *mocks.UserRepository, reviewReassigner *mocks.ReviewReassigner) {
+				userRepository.On("GetUserByID", ctx, "user1").Return(activeUser, nil).Once()
+				userRepository.On("UpdateStatus", ctx, "user1", false).Return(inactiveUser, nil).Once()
+				reviewReassigner.On("ReassignUserReviews", ctx, "user1").Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "reassign reviews: db error",
+		},
+		{
+			name:     "Error on audit record",
+			isActive: false,
+			auditErr: errors.New("db error"),
+			setupMocks: func(userRepository *mocks.UserRepository, reviewReassigner *mocks.ReviewReassigner) {
+				userRepository.On("GetUserByID", ctx, "user1").Return(activeUser, nil).Once()
+				userRepository.On("UpdateStatus", ctx, "user1", false).Return(inactiveUser, nil).Once()
+			},
+			expectedError: "record audit event: db error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userRepository := new(mocks.UserRepository)
+			reviewReassigner := new(mocks.ReviewReassigner)
+			txManager := new(mocks.TxManager)
+			auditRecorder := new(mocks.AuditRecorder)
+			test.setupMocks(userRepository, reviewReassigner)
+			auditRecorder.On("Record", ctx, mock.MatchedBy(func(event model.AuditEvent) bool {
+				return event.Action == model.AuditActionSetActive && event.TargetID == "user1" && event.TeamName == "team-a"
+			})).Return(test.auditErr).Maybe()
+
+			s := user.NewUserService(userRepository, reviewReassigner, txManager, auditRecorder)
+			change, err := s.SetActiveStatus(ctx, "user1", test.isActive)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				assert.Nil(t, change)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedChange, change)
+			}
+			assert.Equal(t, 1, txManager.Calls)
+
+			userRepository.AssertExpectations(t)
+			reviewReassigner.AssertExpectations(t)
+		})
+	}
+}
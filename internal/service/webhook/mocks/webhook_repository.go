@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookRepository struct {
+	mock.Mock
+}
+
+func (m *WebhookRepository) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, sub)
+	var created *model.WebhookSubscription
+	if args.Get(0) != nil {
+		created = args.Get(0).(*model.WebhookSubscription)
+	}
+	return created, args.Error(1)
+}
+
+func (m *WebhookRepository) GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	var sub *model.WebhookSubscription
+	if args.Get(0) != nil {
+		sub = args.Get(0).(*model.WebhookSubscription)
+	}
+	return sub, args.Error(1)
+}
+
+func (m *WebhookRepository) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	var subs []model.WebhookSubscription
+	if args.Get(0) != nil {
+		subs = args.Get(0).([]model.WebhookSubscription)
+	}
+	return subs, args.Error(1)
+}
+
+func (m *WebhookRepository) UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *WebhookRepository) EnqueueDeliveries(ctx context.Context, eventType model.EventType, payload []byte) error {
+	args := m.Called(ctx, eventType, payload)
+	return args.Error(0)
+}
+
+func (m *WebhookRepository) ClaimDelivery(ctx context.Context, lease time.Duration) (*model.WebhookDelivery, error) {
+	args := m.Called(ctx, lease)
+	var delivery *model.WebhookDelivery
+	if args.Get(0) != nil {
+		delivery = args.Get(0).(*model.WebhookDelivery)
+	}
+	return delivery, args.Error(1)
+}
+
+func (m *WebhookRepository) MarkDelivered(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *WebhookRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error {
+	args := m.Called(ctx, id, lastError, nextAttemptAt)
+	return args.Error(0)
+}
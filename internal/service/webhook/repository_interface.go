@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int64) error
+	EnqueueDeliveries(ctx context.Context, eventType model.EventType, payload []byte) error
+	ClaimDelivery(ctx context.Context, lease time.Duration) (*model.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt *time.Time) error
+}
@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/tenant"
+)
+
+const (
+	SignatureHeader = "X-Signature-256"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+type DeliveryPolicy struct {
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	Lease       time.Duration
+}
+
+type WebhookService struct {
+	webhookRepository WebhookRepository
+	client            *http.Client
+	policy            DeliveryPolicy
+}
+
+func NewWebhookService(webhookRepository WebhookRepository, client *http.Client, policy DeliveryPolicy) *WebhookService {
+	return &WebhookService{
+		webhookRepository: webhookRepository,
+		client:            client,
+		policy:            policy,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	if err := validateSubscription(sub); err != nil {
+		return nil, err
+	}
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("generate secret: %w", err)
+		}
+		sub.Secret = secret
+	}
+	return s.webhookRepository.CreateSubscription(ctx, sub)
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error) {
+	return s.webhookRepository.GetSubscription(ctx, id)
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	return s.webhookRepository.ListSubscriptions(ctx)
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	if err := validateSubscription(sub); err != nil {
+		return nil, err
+	}
+	if err := s.webhookRepository.UpdateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return s.webhookRepository.GetSubscription(ctx, sub.ID)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	return s.webhookRepository.DeleteSubscription(ctx, id)
+}
+
+func (s *WebhookService) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookService) Handle(ctx context.Context, event model.OutboxEvent) error {
+	if err := s.webhookRepository.EnqueueDeliveries(tenant.WithTenant(ctx, event.TenantID), event.Type, event.Payload); err != nil {
+		return fmt.Errorf("enqueue deliveries: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) DeliverPending(ctx context.Context, limit int) error {
+	var errs []error
+	for range limit {
+		delivery, err := s.webhookRepository.ClaimDelivery(ctx, s.policy.Lease)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("claim delivery: %w", err))
+			break
+		}
+		if delivery == nil {
+			break
+		}
+
+		if sendErr := s.send(ctx, *delivery); sendErr != nil {
+			if err := s.webhookRepository.MarkFailed(ctx, delivery.ID, sendErr.Error(), s.nextAttemptAt(delivery.Attempts)); err != nil {
+				errs = append(errs, fmt.Errorf("mark delivery %d failed: %w", delivery.ID, err))
+			}
+			continue
+		}
+		if err := s.webhookRepository.MarkDelivered(ctx, delivery.ID); err != nil {
+			errs = append(errs, fmt.Errorf("mark delivery %d delivered: %w", delivery.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *WebhookService) send(ctx context.Context, delivery model.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.EventType))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *WebhookService) nextAttemptAt(attempts int) *time.Time {
+	if attempts >= s.policy.MaxAttempts {
+		return nil
+	}
+
+	backoff := s.policy.BackoffBase << (attempts - 1)
+	if backoff <= 0 || backoff > s.policy.BackoffMax {
+		backoff = s.policy.BackoffMax
+	}
+	next := time.Now().Add(backoff)
+	return &next
+}
+
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateSubscription(sub *model.WebhookSubscription) error {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return model.ErrInvalidWebhookURL
+	}
+	if len(sub.Events) == 0 {
+		return model.ErrNoWebhookEvents
+	}
+	for _, event := range sub.Events {
+		if !event.IsValid() {
+			return model.ErrInvalidEventType
+		}
+	}
+	sub.Events = slices.Compact(slices.Sorted(slices.Values(sub.Events)))
+	return nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,174 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/service/webhook"
+	"github.com/avito/internship/pr-service/internal/service/webhook/mocks"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var policy = webhook.DeliveryPolicy{
+	MaxAttempts: 3,
+	BackoffBase: time.Minute,
+	BackoffMax:  time.Hour,
+	Lease:       time.Minute,
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		sub           *model.WebhookSubscription
+		expectedError string
+	}{
+		{
+			name: "Success - secret generated",
+			sub: &model.WebhookSubscription{
+				URL:    "https://chat.example.com/hook",
+				Events: []model.EventType{model.EventPullRequestMerged, model.EventPullRequestCreated, model.EventPullRequestMerged},
+			},
+		},
+		{
+			name:          "Error - relative url",
+			sub:           &model.WebhookSubscription{URL: "/hook", Events: []model.EventType{model.EventPullRequestCreated}},
+			expectedError: "url must be an absolute http or https URL",
+		},
+		{
+			name:          "Error - no events",
+			sub:           &model.WebhookSubscription{URL: "https://chat.example.com/hook"},
+			expectedError: "events must not be empty",
+		},
+		{
+			name:          "Error - unknown event",
+			sub:           &model.WebhookSubscription{URL: "https://chat.example.com/hook", Events: []model.EventType{"pull_request.deleted"}},
+			expectedError: "unknown event type",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhookRepository := new(mocks.WebhookRepository)
+			if test.expectedError == "" {
+				webhookRepository.On("CreateSubscription", ctx, test.sub).Return(test.sub, nil).Once()
+			}
+
+			s := webhook.NewWebhookService(webhookRepository, http.DefaultClient, policy)
+			sub, err := s.CreateSubscription(ctx, test.sub)
+
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, sub.Secret, 64)
+				assert.Equal(t, []model.EventType{model.EventPullRequestCreated, model.EventPullRequestMerged}, sub.Events)
+			}
+			webhookRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_Handle(t *testing.T) {
+	ctx := context.Background()
+	event := model.OutboxEvent{
+		ID:       7,
+		TenantID: "acme",
+		Type:     model.EventReviewerReassigned,
+		Payload:  []byte(`{"type":"pull_request.reassigned"}`),
+	}
+
+	webhookRepository := new(mocks.WebhookRepository)
+	webhookRepository.On("EnqueueDeliveries", tenant.WithTenant(ctx, "acme"), model.EventReviewerReassigned, event.Payload).Return(nil).Once()
+
+	s := webhook.NewWebhookService(webhookRepository, http.DefaultClient, policy)
+	assert.NoError(t, s.Handle(ctx, event))
+	webhookRepository.AssertExpectations(t)
+}
+
+func TestWebhookService_DeliverPending(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{"type":"pull_request.merged"}`)
+
+	tests := []struct {
+		name       string
+		status     int
+		attempts   int
+		setupMocks func(*mocks.WebhookRepository)
+	}{
+		{
+			name:     "Success - delivered",
+			status:   http.StatusOK,
+			attempts: 1,
+			setupMocks: func(webhookRepository *mocks.WebhookRepository) {
+				webhookRepository.On("MarkDelivered", ctx, int64(7)).Return(nil).Once()
+			},
+		},
+		{
+			name:     "Failure - retried with backoff",
+			status:   http.StatusInternalServerError,
+			attempts: 2,
+			setupMocks: func(webhookRepository *mocks.WebhookRepository) {
+				webhookRepository.On("MarkFailed", ctx, int64(7), "unexpected status 500", mock.AnythingOfType("*time.Time")).Run(func(args mock.Arguments) {
+					next := args.Get(3).(*time.Time)
+					assert.NotNil(t, next)
+					assert.WithinDuration(t, time.Now().Add(2*time.Minute), *next, 5*time.Second)
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name:     "Failure - attempts exhausted",
+			status:   http.StatusBadGateway,
+			attempts: 3,
+			setupMocks: func(webhookRepository *mocks.WebhookRepository) {
+				webhookRepository.On("MarkFailed", ctx, int64(7), "unexpected status 502", (*time.Time)(nil)).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, payload, body)
+				assert.Equal(t, webhook.Sign("s3cret", payload), r.Header.Get(webhook.SignatureHeader))
+				assert.Equal(t, "pull_request.merged", r.Header.Get(webhook.EventHeader))
+				assert.Equal(t, "7", r.Header.Get(webhook.DeliveryHeader))
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			webhookRepository := new(mocks.WebhookRepository)
+			webhookRepository.On("ClaimDelivery", ctx, time.Minute).Return(&model.WebhookDelivery{
+				ID:        7,
+				URL:       server.URL,
+				Secret:    "s3cret",
+				EventType: model.EventPullRequestMerged,
+				Payload:   payload,
+				Attempts:  test.attempts,
+			}, nil).Once()
+			webhookRepository.On("ClaimDelivery", ctx, time.Minute).Return(nil, nil).Once()
+			test.setupMocks(webhookRepository)
+
+			s := webhook.NewWebhookService(webhookRepository, server.Client(), policy)
+			assert.NoError(t, s.DeliverPending(ctx, 10))
+			webhookRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t,
+		"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17",
+		webhook.Sign("It's a Secret to Everybody", []byte("Hello, World!")),
+	)
+}
@@ -0,0 +1,61 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
+)
+
+const (
+	Default = "default"
+	Header  = "X-Tenant-ID"
+)
+
+type contextKey struct{}
+
+type requestedKey struct{}
+
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+func FromContext(ctx context.Context) string {
+	if tenantID, ok := ctx.Value(contextKey{}).(string); ok && tenantID != "" {
+		return tenantID
+	}
+
+	p, authenticated := principal.FromContext(ctx)
+	if authenticated && p.TenantID != "" {
+		return p.TenantID
+	}
+	if authenticated && p.Role == model.RoleAdmin {
+		if tenantID, ok := ctx.Value(requestedKey{}).(string); ok && tenantID != "" {
+			return tenantID
+		}
+	}
+	return Default
+}
+
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tenantID := strings.TrimSpace(r.Header.Get(Header)); tenantID != "" {
+			r = r.WithContext(context.WithValue(r.Context(), requestedKey{}, tenantID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func ForEach(ctx context.Context, tenantIDs []string, fn func(ctx context.Context) error) error {
+	var errs []error
+	for _, tenantID := range tenantIDs {
+		if err := fn(WithTenant(ctx, tenantID)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenantID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
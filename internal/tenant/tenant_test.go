@@ -0,0 +1,74 @@
+package tenant_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avito/internship/pr-service/internal/model"
+	"github.com/avito/internship/pr-service/internal/principal"
+	"github.com/avito/internship/pr-service/internal/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	member := &model.Principal{UserID: "user1", Role: model.RoleMember, TenantID: "payments"}
+	memberWithoutTenant := &model.Principal{UserID: "user2", Role: model.RoleMember}
+	admin := &model.Principal{Subject: "admin", Role: model.RoleAdmin}
+
+	tests := []struct {
+		name      string
+		principal *model.Principal
+		header    string
+		explicit  string
+		expected  string
+	}{
+		{name: "Default without principal and header", expected: tenant.Default},
+		{name: "Header ignored without principal", header: "logistics", expected: tenant.Default},
+		{name: "Principal tenant wins over header", principal: member, header: "logistics", expected: "payments"},
+		{name: "Header ignored for member without tenant", principal: memberWithoutTenant, header: "logistics", expected: tenant.Default},
+		{name: "Header used for admin without tenant", principal: admin, header: "logistics", expected: "logistics"},
+		{name: "Explicit tenant wins", principal: member, explicit: "search", expected: "search"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var resolved string
+			handler := tenant.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				if test.principal != nil {
+					ctx = principal.WithPrincipal(ctx, test.principal)
+				}
+				if test.explicit != "" {
+					ctx = tenant.WithTenant(ctx, test.explicit)
+				}
+				resolved = tenant.FromContext(ctx)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.header != "" {
+				req.Header.Set(tenant.Header, test.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, test.expected, resolved)
+		})
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var visited []string
+	err := tenant.ForEach(context.Background(), []string{"payments", "logistics"}, func(ctx context.Context) error {
+		tenantID := tenant.FromContext(ctx)
+		visited = append(visited, tenantID)
+		if tenantID == "payments" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, err, "tenant payments: boom")
+	assert.Equal(t, []string{"payments", "logistics"}, visited)
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_reviewers_user_id ON reviewers(user_id);
+CREATE INDEX IF NOT EXISTS idx_reviewers_pull_request_id ON reviewers(pull_request_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reviewers_pull_request_id;
+DROP INDEX IF EXISTS idx_reviewers_user_id;
+-- +goose StatementEnd
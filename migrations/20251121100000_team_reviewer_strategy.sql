@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS reviewer_strategy VARCHAR(32) NOT NULL DEFAULT 'LEAST_LOADED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewer_strategy;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS min_reviewers INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_reviewers INT NOT NULL DEFAULT 2,
+    ADD CONSTRAINT chk_teams_reviewers_count CHECK (min_reviewers >= 0 AND min_reviewers <= max_reviewers);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS chk_teams_reviewers_count,
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
+-- +goose StatementEnd
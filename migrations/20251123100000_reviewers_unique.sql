@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE reviewers ADD CONSTRAINT uq_reviewers_pull_request_user UNIQUE (pull_request_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS uq_reviewers_pull_request_user;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'CLOSED';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+-- +goose Down
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
+ALTER TYPE pr_status RENAME TO pr_status_old;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
+ALTER TABLE pull_requests ALTER COLUMN status TYPE pr_status USING status::text::pr_status;
+DROP TYPE pr_status_old;
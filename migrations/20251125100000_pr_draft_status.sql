@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'DRAFT';
+
+-- +goose Down
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'DRAFT';
+ALTER TYPE pr_status RENAME TO pr_status_old;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED', 'CLOSED');
+ALTER TABLE pull_requests ALTER COLUMN status TYPE pr_status USING status::text::pr_status;
+DROP TYPE pr_status_old;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE review_state AS ENUM ('PENDING', 'APPROVED', 'CHANGES_REQUESTED');
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS state review_state NOT NULL DEFAULT 'PENDING';
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP NOT NULL DEFAULT now();
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+UPDATE reviewers r SET assigned_at = pr.created_at
+FROM pull_requests pr
+WHERE pr.pull_request_id = r.pull_request_id AND pr.created_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reviewers DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS assigned_at;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS state;
+DROP TYPE IF EXISTS review_state;
+-- +goose StatementEnd
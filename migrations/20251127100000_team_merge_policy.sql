@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS min_approvals INT NOT NULL DEFAULT 0;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS block_on_changes_requested BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS author_cannot_merge BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS merged_by VARCHAR(50);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS merge_override BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS merge_override;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS merged_by;
+ALTER TABLE teams DROP COLUMN IF EXISTS author_cannot_merge;
+ALTER TABLE teams DROP COLUMN IF EXISTS block_on_changes_requested;
+ALTER TABLE teams DROP COLUMN IF EXISTS min_approvals;
+-- +goose StatementEnd
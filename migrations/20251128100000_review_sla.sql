@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_sla_hours INT NOT NULL DEFAULT 0;
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS due_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_reviewers_due_at ON reviewers(due_at) WHERE state = 'PENDING';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reviewers_due_at;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS due_at;
+ALTER TABLE teams DROP COLUMN IF EXISTS review_sla_hours;
+-- +goose StatementEnd
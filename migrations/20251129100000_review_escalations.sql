@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS review_escalations (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(50) NOT NULL REFERENCES pull_requests(pull_request_id),
+    old_reviewer_id VARCHAR(50) NOT NULL REFERENCES users(user_id),
+    new_reviewer_id VARCHAR(50) NOT NULL REFERENCES users(user_id),
+    reason TEXT NOT NULL,
+    escalated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_review_escalations_pull_request_id ON review_escalations(pull_request_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_review_escalations_pull_request_id;
+DROP TABLE IF EXISTS review_escalations;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS external_identities (
+    provider VARCHAR(32) NOT NULL,
+    external_login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, external_login)
+);
+CREATE INDEX IF NOT EXISTS idx_external_identities_user_id ON external_identities(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_external_identities_user_id;
+DROP TABLE IF EXISTS external_identities;
+-- +goose StatementEnd
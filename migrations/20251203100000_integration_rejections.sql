@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS integration_rejections (
+    id BIGSERIAL PRIMARY KEY,
+    provider VARCHAR(32) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    pull_request_id VARCHAR(50) NOT NULL,
+    external_login VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_integration_rejections_created_at ON integration_rejections(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_integration_rejections_created_at;
+DROP TABLE IF EXISTS integration_rejections;
+-- +goose StatementEnd
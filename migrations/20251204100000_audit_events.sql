@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id VARCHAR(255) NOT NULL,
+    pull_request_id VARCHAR(50),
+    user_id VARCHAR(50),
+    team_name VARCHAR(255),
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_pull_request_id ON audit_events(pull_request_id) WHERE pull_request_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor);
+CREATE INDEX IF NOT EXISTS idx_audit_events_team_name ON audit_events(team_name) WHERE team_name IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
+DROP INDEX IF EXISTS idx_audit_events_team_name;
+DROP INDEX IF EXISTS idx_audit_events_actor;
+DROP INDEX IF EXISTS idx_audit_events_user_id;
+DROP INDEX IF EXISTS idx_audit_events_pull_request_id;
+DROP INDEX IF EXISTS idx_audit_events_created_at;
+DROP TABLE IF EXISTS audit_events;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS id BIGSERIAL PRIMARY KEY;
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS reason VARCHAR(32) NOT NULL DEFAULT 'initial';
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS unassigned_at TIMESTAMP;
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS uq_reviewers_pull_request_user;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reviewers_active_pull_request_user ON reviewers(pull_request_id, user_id) WHERE unassigned_at IS NULL;
+DROP INDEX IF EXISTS idx_reviewers_due_at;
+CREATE INDEX IF NOT EXISTS idx_reviewers_due_at ON reviewers(due_at) WHERE state = 'PENDING' AND unassigned_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM reviewers WHERE unassigned_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_reviewers_due_at;
+CREATE INDEX IF NOT EXISTS idx_reviewers_due_at ON reviewers(due_at) WHERE state = 'PENDING';
+DROP INDEX IF EXISTS uq_reviewers_active_pull_request_user;
+ALTER TABLE reviewers ADD CONSTRAINT uq_reviewers_pull_request_user UNIQUE (pull_request_id, user_id);
+ALTER TABLE reviewers DROP COLUMN IF EXISTS unassigned_at;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS reason;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS id;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE review_escalations ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS reviewers_pull_request_id_fkey;
+ALTER TABLE review_escalations DROP CONSTRAINT IF EXISTS review_escalations_pull_request_id_fkey;
+
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_pkey;
+ALTER TABLE teams ADD CONSTRAINT teams_pkey PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (tenant_id, pull_request_id);
+
+ALTER TABLE users ADD CONSTRAINT users_team_fkey
+    FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, team_name);
+ALTER TABLE reviewers ADD CONSTRAINT reviewers_pull_request_fkey
+    FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests(tenant_id, pull_request_id);
+ALTER TABLE review_escalations ADD CONSTRAINT review_escalations_pull_request_fkey
+    FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests(tenant_id, pull_request_id);
+
+DROP INDEX IF EXISTS idx_users_team_name;
+CREATE INDEX IF NOT EXISTS idx_users_tenant_team_name ON users(tenant_id, team_name);
+DROP INDEX IF EXISTS idx_reviewers_pull_request_id;
+CREATE INDEX IF NOT EXISTS idx_reviewers_tenant_pull_request_id ON reviewers(tenant_id, pull_request_id);
+DROP INDEX IF EXISTS uq_reviewers_active_pull_request_user;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reviewers_active_pull_request_user ON reviewers(tenant_id, pull_request_id, user_id) WHERE unassigned_at IS NULL;
+DROP INDEX IF EXISTS idx_review_escalations_pull_request_id;
+CREATE INDEX IF NOT EXISTS idx_review_escalations_pull_request_id ON review_escalations(tenant_id, pull_request_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_review_escalations_pull_request_id;
+CREATE INDEX IF NOT EXISTS idx_review_escalations_pull_request_id ON review_escalations(pull_request_id);
+DROP INDEX IF EXISTS uq_reviewers_active_pull_request_user;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reviewers_active_pull_request_user ON reviewers(pull_request_id, user_id) WHERE unassigned_at IS NULL;
+DROP INDEX IF EXISTS idx_reviewers_tenant_pull_request_id;
+CREATE INDEX IF NOT EXISTS idx_reviewers_pull_request_id ON reviewers(pull_request_id);
+DROP INDEX IF EXISTS idx_users_tenant_team_name;
+CREATE INDEX IF NOT EXISTS idx_users_team_name ON users(team_name);
+
+ALTER TABLE review_escalations DROP CONSTRAINT IF EXISTS review_escalations_pull_request_fkey;
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS reviewers_pull_request_fkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_fkey;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (pull_request_id);
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_pkey;
+ALTER TABLE teams ADD CONSTRAINT teams_pkey PRIMARY KEY (team_name);
+
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name) REFERENCES teams(team_name);
+ALTER TABLE reviewers ADD CONSTRAINT reviewers_pull_request_id_fkey FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id);
+ALTER TABLE review_escalations ADD CONSTRAINT review_escalations_pull_request_id_fkey FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id);
+
+ALTER TABLE review_escalations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE reviewers DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE external_identities ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE integration_rejections ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE external_identities DROP CONSTRAINT IF EXISTS external_identities_pkey;
+ALTER TABLE external_identities ADD CONSTRAINT external_identities_pkey PRIMARY KEY (tenant_id, provider, external_login);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant_id ON webhook_subscriptions(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_tenant_id ON audit_events(tenant_id, id);
+CREATE INDEX IF NOT EXISTS idx_integration_rejections_tenant_id ON integration_rejections(tenant_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_integration_rejections_tenant_id;
+DROP INDEX IF EXISTS idx_audit_events_tenant_id;
+DROP INDEX IF EXISTS idx_webhook_subscriptions_tenant_id;
+
+ALTER TABLE external_identities DROP CONSTRAINT IF EXISTS external_identities_pkey;
+ALTER TABLE external_identities ADD CONSTRAINT external_identities_pkey PRIMARY KEY (provider, external_login);
+
+ALTER TABLE integration_rejections DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE external_identities DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE audit_events DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhook_subscriptions DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64);
+UPDATE api_tokens t SET tenant_id = u.tenant_id FROM users u WHERE u.user_id = t.user_id;
+ALTER TABLE api_tokens ADD CONSTRAINT api_tokens_user_tenant_check CHECK (user_id IS NULL OR tenant_id IS NOT NULL);
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey;
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS reviewers_user_id_fkey;
+ALTER TABLE review_escalations DROP CONSTRAINT IF EXISTS review_escalations_old_reviewer_id_fkey;
+ALTER TABLE review_escalations DROP CONSTRAINT IF EXISTS review_escalations_new_reviewer_id_fkey;
+ALTER TABLE external_identities DROP CONSTRAINT IF EXISTS external_identities_user_id_fkey;
+ALTER TABLE api_tokens DROP CONSTRAINT IF EXISTS api_tokens_user_id_fkey;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (tenant_id, user_id);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users ADD CONSTRAINT users_tenant_username_key UNIQUE (tenant_id, username);
+
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_fkey
+    FOREIGN KEY (tenant_id, author_id) REFERENCES users(tenant_id, user_id);
+ALTER TABLE reviewers ADD CONSTRAINT reviewers_user_fkey
+    FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id);
+ALTER TABLE review_escalations ADD CONSTRAINT review_escalations_old_reviewer_fkey
+    FOREIGN KEY (tenant_id, old_reviewer_id) REFERENCES users(tenant_id, user_id);
+ALTER TABLE review_escalations ADD CONSTRAINT review_escalations_new_reviewer_fkey
+    FOREIGN KEY (tenant_id, new_reviewer_id) REFERENCES users(tenant_id, user_id);
+ALTER TABLE external_identities ADD CONSTRAINT external_identities_user_fkey
+    FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE api_tokens ADD CONSTRAINT api_tokens_user_fkey
+    FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id);
+
+DROP INDEX IF EXISTS idx_reviewers_user_id;
+CREATE INDEX IF NOT EXISTS idx_reviewers_tenant_user_id ON reviewers(tenant_id, user_id);
+DROP INDEX IF EXISTS idx_external_identities_user_id;
+CREATE INDEX IF NOT EXISTS idx_external_identities_tenant_user_id ON external_identities(tenant_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_external_identities_tenant_user_id;
+CREATE INDEX IF NOT EXISTS idx_external_identities_user_id ON external_identities(user_id);
+DROP INDEX IF EXISTS idx_reviewers_tenant_user_id;
+CREATE INDEX IF NOT EXISTS idx_reviewers_user_id ON reviewers(user_id);
+
+ALTER TABLE api_tokens DROP CONSTRAINT IF EXISTS api_tokens_user_fkey;
+ALTER TABLE external_identities DROP CONSTRAINT IF EXISTS external_identities_user_fkey;
+ALTER TABLE review_escalations DROP CONSTRAINT IF EXISTS review_escalations_new_reviewer_fkey;
+ALTER TABLE review_escalations DROP CONSTRAINT IF EXISTS review_escalations_old_reviewer_fkey;
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS reviewers_user_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_author_fkey;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_username_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (user_id);
+
+ALTER TABLE api_tokens ADD CONSTRAINT api_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(user_id);
+ALTER TABLE external_identities ADD CONSTRAINT external_identities_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE review_escalations ADD CONSTRAINT review_escalations_new_reviewer_id_fkey FOREIGN KEY (new_reviewer_id) REFERENCES users(user_id);
+ALTER TABLE review_escalations ADD CONSTRAINT review_escalations_old_reviewer_id_fkey FOREIGN KEY (old_reviewer_id) REFERENCES users(user_id);
+ALTER TABLE reviewers ADD CONSTRAINT reviewers_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(user_id);
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (author_id) REFERENCES users(user_id);
+
+ALTER TABLE api_tokens DROP CONSTRAINT IF EXISTS api_tokens_user_tenant_check;
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS status_before_close pr_status;
+UPDATE pull_requests SET status_before_close = 'OPEN' WHERE status = 'CLOSED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS status_before_close;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS delivered_sinks TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP NOT NULL DEFAULT now();
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS dead_at TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(next_attempt_at)
+    WHERE delivered_at IS NULL AND dead_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(id)
+    WHERE delivered_at IS NULL;
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS dead_at;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS delivered_sinks;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE reviewers ADD COLUMN IF NOT EXISTS escalation_retry_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reviewers DROP COLUMN IF EXISTS escalation_retry_at;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS fill_retry_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS fill_retry_at;
+-- +goose StatementEnd